@@ -0,0 +1,313 @@
+use std::collections::{HashMap, HashSet};
+use std::fs;
+use std::path::{Path, PathBuf};
+use std::sync::atomic::{AtomicUsize, Ordering};
+use std::thread;
+
+use serde_yaml::Value as YamlValue;
+
+use crate::dto::{
+    build_dto_schema, build_dto_schema_from_json_schema, build_dto_schema_from_openapi,
+    flatten_schema, render_schema, DtoError, DtoLanguage, DtoOptions, DtoSchema, DtoWarning, Field,
+    FieldConstraints, FieldType, SchemaNode, TypeRoot,
+};
+use crate::parse_rule_file;
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub enum BatchMode {
+    PerFile,
+    Merged,
+}
+
+#[derive(Debug, Clone)]
+pub struct DtoBatch {
+    pub outputs: Vec<DtoBatchOutput>,
+    pub failures: Vec<DtoBatchFailure>,
+}
+
+#[derive(Debug, Clone)]
+pub struct DtoBatchOutput {
+    pub file_name: String,
+    pub sources: Vec<PathBuf>,
+    pub code: String,
+    pub warnings: Vec<DtoWarning>,
+}
+
+#[derive(Debug, Clone)]
+pub struct DtoBatchFailure {
+    pub source: PathBuf,
+    pub error: DtoError,
+}
+
+pub fn generate_dto_batch(
+    dir: &Path,
+    language: DtoLanguage,
+    options: &DtoOptions,
+    mode: BatchMode,
+) -> Result<DtoBatch, DtoError> {
+    let jobs = thread::available_parallelism().map_or(1, |jobs| jobs.get());
+    generate_dto_batch_with_jobs(dir, language, options, mode, jobs)
+}
+
+pub fn generate_dto_batch_with_jobs(
+    dir: &Path,
+    language: DtoLanguage,
+    options: &DtoOptions,
+    mode: BatchMode,
+    jobs: usize,
+) -> Result<DtoBatch, DtoError> {
+    let read_error =
+        |err: std::io::Error| DtoError::new(format!("failed to read {}: {}", dir.display(), err));
+    let mut sources = Vec::new();
+    for entry in fs::read_dir(dir).map_err(read_error)? {
+        let path = entry.map_err(read_error)?.path();
+        let extension = path.extension().and_then(|ext| ext.to_str()).unwrap_or("");
+        if path.is_file() && matches!(extension, "yaml" | "yml" | "json") {
+            sources.push(path);
+        }
+    }
+    sources.sort();
+
+    let mut batch = DtoBatch {
+        outputs: Vec::new(),
+        failures: Vec::new(),
+    };
+    let results = run_parallel(&sources, jobs, |source| {
+        let DtoSchema { root, schema } = load_schema(source)?;
+        match mode {
+            BatchMode::PerFile => {
+                let (code, warnings) =
+                    render_schema(schema, root.as_type_root(), language, options)?;
+                Ok(Loaded::File(code, warnings))
+            }
+            BatchMode::Merged => Ok(Loaded::Types(flatten_schema(&schema, root.as_type_root()))),
+        }
+    });
+
+    let mut merged = MergedTypes::default();
+    let mut merged_sources = Vec::new();
+    for (source, result) in sources.into_iter().zip(results) {
+        match result {
+            Ok(Loaded::File(code, warnings)) => {
+                let stem = source.file_stem().and_then(|stem| stem.to_str()).unwrap_or("dto");
+                batch.outputs.push(DtoBatchOutput {
+                    file_name: format!("{}.{}", stem, file_extension(language)),
+                    sources: vec![source],
+                    code,
+                    warnings,
+                });
+            }
+            Ok(Loaded::Types(types)) => {
+                merged.merge(types);
+                merged_sources.push(source);
+            }
+            Err(error) if options.strict => {
+                return Err(DtoError::new(format!("{}: {}", source.display(), error)));
+            }
+            Err(error) => batch.failures.push(DtoBatchFailure { source, error }),
+        }
+    }
+
+    if mode == BatchMode::Merged && !merged_sources.is_empty() {
+        let (code, warnings) =
+            render_schema(merged.into_schema(), TypeRoot::Components, language, options)?;
+        batch.outputs.push(DtoBatchOutput {
+            file_name: format!("dto.{}", file_extension(language)),
+            sources: merged_sources,
+            code,
+            warnings,
+        });
+    }
+    Ok(batch)
+}
+
+enum Loaded {
+    File(String, Vec<DtoWarning>),
+    Types(Vec<(String, SchemaNode)>),
+}
+
+fn run_parallel<T, F>(sources: &[PathBuf], jobs: usize, task: F) -> Vec<T>
+where
+    T: Send,
+    F: Fn(&Path) -> T + Sync,
+{
+    let workers = jobs.min(sources.len());
+    if workers <= 1 {
+        return sources.iter().map(|source| task(source)).collect();
+    }
+    let next = AtomicUsize::new(0);
+    let mut results: Vec<(usize, T)> = thread::scope(|scope| {
+        let handles: Vec<_> = (0..workers)
+            .map(|_| {
+                scope.spawn(|| {
+                    let mut done = Vec::new();
+                    loop {
+                        let index = next.fetch_add(1, Ordering::Relaxed);
+                        let Some(source) = sources.get(index) else {
+                            break;
+                        };
+                        done.push((index, task(source)));
+                    }
+                    done
+                })
+            })
+            .collect();
+        handles
+            .into_iter()
+            .flat_map(|handle| handle.join().expect("batch worker panicked"))
+            .collect()
+    });
+    results.sort_by_key(|(index, _)| *index);
+    results.into_iter().map(|(_, result)| result).collect()
+}
+
+fn load_schema(path: &Path) -> Result<DtoSchema, DtoError> {
+    if path.extension().and_then(|ext| ext.to_str()) == Some("json") {
+        return build_dto_schema_from_json_schema(path, None);
+    }
+    let source = fs::read_to_string(path)
+        .map_err(|err| DtoError::new(format!("failed to read {}: {}", path.display(), err)))?;
+    let document: YamlValue = serde_yaml::from_str(&source)
+        .map_err(|err| DtoError::new(format!("invalid YAML: {}", err)))?;
+    if document.get("openapi").is_some() {
+        return build_dto_schema_from_openapi(&source);
+    }
+    let rule = parse_rule_file(&source)
+        .map_err(|err| DtoError::new(format!("failed to parse rules: {}", err)))?;
+    build_dto_schema(&rule, None)
+}
+
+fn file_extension(language: DtoLanguage) -> &'static str {
+    match language {
+        DtoLanguage::Rust => "rs",
+        DtoLanguage::TypeScript => "ts",
+        DtoLanguage::Python | DtoLanguage::Pydantic => "py",
+        DtoLanguage::Go => "go",
+        DtoLanguage::Java => "java",
+        DtoLanguage::Kotlin => "kt",
+        DtoLanguage::Swift => "swift",
+        DtoLanguage::Protobuf => "proto",
+        DtoLanguage::JsonSchema => "json",
+    }
+}
+
+#[derive(Default)]
+struct MergedTypes {
+    types: Vec<(String, SchemaNode)>,
+}
+
+impl MergedTypes {
+    fn merge(&mut self, file_types: Vec<(String, SchemaNode)>) {
+        let mut renames = HashMap::new();
+        let mut inserted = Vec::new();
+        for (name, mut node) in file_types {
+            rename_refs(&mut node, &renames);
+            let mut candidate = name.clone();
+            let mut suffix = 2;
+            loop {
+                let mut probe = node.clone();
+                rename_refs(&mut probe, &HashMap::from([(name.clone(), candidate.clone())]));
+                match self.types.iter().find(|(existing, _)| *existing == candidate) {
+                    Some((_, existing)) if same_shape(existing, &probe) => break,
+                    Some(_) => {
+                        candidate = format!("{}{}", name, suffix);
+                        suffix += 1;
+                    }
+                    None => {
+                        let known: HashSet<String> = renames.keys().cloned().collect();
+                        inserted.push((self.types.len(), known));
+                        self.types.push((candidate.clone(), probe));
+                        break;
+                    }
+                }
+            }
+            renames.insert(name, candidate);
+        }
+        for (index, known) in inserted {
+            let forward: HashMap<String, String> = renames
+                .iter()
+                .filter(|(name, _)| !known.contains(*name))
+                .map(|(name, renamed)| (name.clone(), renamed.clone()))
+                .collect();
+            rename_refs(&mut self.types[index].1, &forward);
+        }
+    }
+
+    fn into_schema(self) -> SchemaNode {
+        let fields = self
+            .types
+            .into_iter()
+            .map(|(name, node)| Field {
+                key: name,
+                field_type: FieldType::Object(Box::new(node)),
+                optional: false,
+                nullable: false,
+                always_emit: false,
+                deprecated: false,
+                deprecation_message: None,
+                format: None,
+                enum_values: None,
+                doc: None,
+                constraints: FieldConstraints::default(),
+                default: None,
+            })
+            .collect();
+        SchemaNode {
+            fields,
+            doc: None,
+            additional_properties: false,
+            embeds: Vec::new(),
+        }
+    }
+}
+
+fn same_shape(left: &SchemaNode, right: &SchemaNode) -> bool {
+    left.fields.len() == right.fields.len()
+        && left.additional_properties == right.additional_properties
+        && left.embeds == right.embeds
+        && left.fields.iter().zip(&right.fields).all(|(left, right)| {
+            Field {
+                doc: None,
+                ..left.clone()
+            } == Field {
+                doc: None,
+                ..right.clone()
+            }
+        })
+}
+
+fn rename_refs(node: &mut SchemaNode, renames: &HashMap<String, String>) {
+    for embed in &mut node.embeds {
+        if let Some(renamed) = renames.get(embed) {
+            *embed = renamed.clone();
+        }
+    }
+    for field in &mut node.fields {
+        rename_type_refs(&mut field.field_type, renames);
+    }
+}
+
+fn rename_type_refs(field_type: &mut FieldType, renames: &HashMap<String, String>) {
+    match field_type {
+        FieldType::Ref(name) => {
+            if let Some(renamed) = renames.get(name) {
+                *name = renamed.clone();
+            }
+        }
+        FieldType::Array(item) | FieldType::Map(item) => rename_type_refs(item, renames),
+        FieldType::Object(child) => rename_refs(child, renames),
+        FieldType::Union(union) => {
+            for variant in &mut union.variants {
+                if let Some(renamed) = renames.get(&variant.type_name) {
+                    variant.type_name = renamed.clone();
+                }
+            }
+        }
+        FieldType::Tuple(tuple) => {
+            for item in &mut tuple.items {
+                rename_type_refs(item, renames);
+            }
+        }
+        FieldType::Primitive(_) | FieldType::JsonValue => {}
+    }
+}
@@ -0,0 +1,168 @@
+use std::fs;
+use std::io::ErrorKind;
+use std::path::Path;
+
+use crate::dto::DtoError;
+
+const CONTEXT_LINES: usize = 3;
+
+pub fn diff_generated_file(path: &Path, generated: &str) -> Result<Option<String>, DtoError> {
+    let existing = match fs::read_to_string(path) {
+        Ok(existing) => existing,
+        Err(err) if err.kind() == ErrorKind::NotFound => String::new(),
+        Err(err) => {
+            return Err(DtoError::new(format!(
+                "failed to read {}: {}",
+                path.display(),
+                err
+            )));
+        }
+    };
+    let label = path.display().to_string();
+    Ok(unified_diff(
+        &existing,
+        generated,
+        &label,
+        &format!("{} (generated)", label),
+    ))
+}
+
+pub fn unified_diff(old: &str, new: &str, old_label: &str, new_label: &str) -> Option<String> {
+    if old == new {
+        return None;
+    }
+    let old_lines: Vec<&str> = old.split_inclusive('\n').collect();
+    let new_lines: Vec<&str> = new.split_inclusive('\n').collect();
+    let ops = diff_lines(&old_lines, &new_lines);
+
+    let mut out = format!("--- {}\n+++ {}\n", old_label, new_label);
+    for (start, end) in hunk_ranges(&ops) {
+        let (old_start, new_start) = positions(&ops[..start]);
+        let (old_count, new_count) = positions(&ops[start..end]);
+        out.push_str(&format!(
+            "@@ -{} +{} @@\n",
+            hunk_range(old_start, old_count),
+            hunk_range(new_start, new_count)
+        ));
+        for op in &ops[start..end] {
+            let (marker, line) = match op {
+                DiffOp::Equal(line) => (' ', line),
+                DiffOp::Delete(line) => ('-', line),
+                DiffOp::Insert(line) => ('+', line),
+            };
+            out.push(marker);
+            match line.strip_suffix('\n') {
+                Some(line) => {
+                    out.push_str(line);
+                    out.push('\n');
+                }
+                None => {
+                    out.push_str(line);
+                    out.push_str("\n\\ No newline at end of file\n");
+                }
+            }
+        }
+    }
+    Some(out)
+}
+
+enum DiffOp<'a> {
+    Equal(&'a str),
+    Delete(&'a str),
+    Insert(&'a str),
+}
+
+fn diff_lines<'a>(old: &[&'a str], new: &[&'a str]) -> Vec<DiffOp<'a>> {
+    if old.is_empty() || new.is_empty() {
+        let deletes = old.iter().copied().map(DiffOp::Delete);
+        return deletes.chain(new.iter().copied().map(DiffOp::Insert)).collect();
+    }
+    let n = old.len() as isize;
+    let m = new.len() as isize;
+    let offset = n + m;
+    let mut v = vec![0isize; (2 * offset + 2) as usize];
+    let mut trace = Vec::new();
+    'search: for d in 0..=offset {
+        trace.push(v[(offset - d) as usize..=(offset + d + 1) as usize].to_vec());
+        for k in (-d..=d).step_by(2) {
+            let index = (k + offset) as usize;
+            let mut x = if k == -d || (k != d && v[index - 1] < v[index + 1]) {
+                v[index + 1]
+            } else {
+                v[index - 1] + 1
+            };
+            let mut y = x - k;
+            while x < n && y < m && old[x as usize] == new[y as usize] {
+                x += 1;
+                y += 1;
+            }
+            v[index] = x;
+            if x >= n && y >= m {
+                break 'search;
+            }
+        }
+    }
+
+    let mut ops = Vec::new();
+    let (mut x, mut y) = (n, m);
+    for (d, v) in trace.iter().enumerate().rev() {
+        let d = d as isize;
+        let k = x - y;
+        let index = (k + d) as usize;
+        let prev_k = if k == -d || (k != d && v[index - 1] < v[index + 1]) {
+            k + 1
+        } else {
+            k - 1
+        };
+        let prev_x = v[(prev_k + d) as usize];
+        let prev_y = prev_x - prev_k;
+        while x > prev_x && y > prev_y {
+            ops.push(DiffOp::Equal(old[(x - 1) as usize]));
+            x -= 1;
+            y -= 1;
+        }
+        if d > 0 {
+            if x == prev_x {
+                ops.push(DiffOp::Insert(new[(y - 1) as usize]));
+            } else {
+                ops.push(DiffOp::Delete(old[(x - 1) as usize]));
+            }
+        }
+        x = prev_x;
+        y = prev_y;
+    }
+    ops.reverse();
+    ops
+}
+
+fn hunk_ranges(ops: &[DiffOp]) -> Vec<(usize, usize)> {
+    let mut ranges: Vec<(usize, usize)> = Vec::new();
+    for (index, op) in ops.iter().enumerate() {
+        if matches!(op, DiffOp::Equal(_)) {
+            continue;
+        }
+        let start = index.saturating_sub(CONTEXT_LINES);
+        let end = (index + 1 + CONTEXT_LINES).min(ops.len());
+        match ranges.last_mut() {
+            Some(last) if start <= last.1 => last.1 = end,
+            _ => ranges.push((start, end)),
+        }
+    }
+    ranges
+}
+
+fn positions(ops: &[DiffOp]) -> (usize, usize) {
+    ops.iter().fold((0, 0), |(old, new), op| match op {
+        DiffOp::Equal(_) => (old + 1, new + 1),
+        DiffOp::Delete(_) => (old + 1, new),
+        DiffOp::Insert(_) => (old, new + 1),
+    })
+}
+
+fn hunk_range(before: usize, count: usize) -> String {
+    match count {
+        0 => format!("{},0", before),
+        1 => format!("{}", before + 1),
+        _ => format!("{},{}", before + 1, count),
+    }
+}
@@ -1,138 +1,795 @@
-use std::collections::{HashMap, HashSet};
+use std::collections::{BTreeMap, HashMap, HashSet};
+use std::path::Path;
 
+use chrono::{SecondsFormat, Utc};
+use serde::{Deserialize, Serialize};
 use serde_json::Value as JsonValue;
 
-use crate::model::{Expr, RuleFile};
+use crate::json_schema::build_json_schema;
+use crate::model::{DtoField, DtoHint, EnumLiteral, EnumValue, Expr, Mapping, RuleFile};
+use crate::openapi::build_openapi_schema;
 use crate::path::{parse_path, PathToken};
+use crate::schema_validator::schema_errors;
+use crate::template::render_template;
 
 #[derive(Debug, Clone, Copy, PartialEq, Eq)]
 pub enum DtoLanguage {
     Rust,
     TypeScript,
     Python,
+    Pydantic,
     Go,
     Java,
     Kotlin,
     Swift,
+    Protobuf,
+    JsonSchema,
 }
 
 #[derive(Debug, Clone)]
 pub struct DtoError {
     message: String,
+    field: Option<String>,
 }
 
 impl DtoError {
-    fn new(message: impl Into<String>) -> Self {
+    pub(crate) fn new(message: impl Into<String>) -> Self {
         Self {
             message: message.into(),
+            field: None,
         }
     }
+
+    pub(crate) fn with_field(mut self, field: &str) -> Self {
+        self.field = Some(match self.field {
+            Some(inner) => format!("{}.{}", field, inner),
+            None => field.to_string(),
+        });
+        self
+    }
 }
 
 impl std::fmt::Display for DtoError {
     fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
-        write!(f, "{}", self.message)
+        match &self.field {
+            Some(field) => write!(f, "{}: {}", field, self.message),
+            None => write!(f, "{}", self.message),
+        }
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct DtoWarning {
+    pub path: String,
+    pub message: String,
+}
+
+impl std::fmt::Display for DtoWarning {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        write!(f, "{}: {}", self.path, self.message)
     }
 }
 
 impl std::error::Error for DtoError {}
 
+#[derive(Debug, Clone, Default)]
+pub struct DtoOptions {
+    pub field_order: FieldOrder,
+    pub strict: bool,
+    pub merge_identical_types: bool,
+    pub go: GoOptions,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Default)]
+pub enum FieldOrder {
+    #[default]
+    Declaration,
+    Alphabetical,
+}
+
+#[derive(Debug, Clone)]
+pub struct GoOptions {
+    pub package_name: String,
+    pub format_types: HashMap<String, GoType>,
+    pub type_overrides: HashMap<String, GoType>,
+    pub tag_naming: TagNamingStrategy,
+    pub optional_strategy: OptionalStrategy,
+    pub emit_enums: bool,
+    pub emit_validation: bool,
+    pub emit_unions: bool,
+    pub emit_union_unmarshal: bool,
+    pub emit_tuples: bool,
+    pub emit_tuple_marshal: bool,
+    pub emit_raw_decoders: bool,
+    pub inline_anonymous: bool,
+    pub emit_constructors: bool,
+    pub emit_getters: bool,
+    pub emit_validate_method: bool,
+    pub additional_properties: AdditionalPropertiesPolicy,
+    pub go_version: Option<String>,
+    pub json_number: bool,
+    pub template: Option<String>,
+    pub header: GoHeader,
+    pub unknown_type: GoType,
+    pub indent: GoIndent,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Default)]
+pub enum TagNamingStrategy {
+    #[default]
+    AsIs,
+    SnakeCase,
+    CamelCase,
+    PascalCase,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Default)]
+pub enum OptionalStrategy {
+    #[default]
+    PointerWithOmitempty,
+    ValueWithOmitempty,
+    AlwaysPointer,
+    PointerForNullable,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Default)]
+pub enum GoHeader {
+    #[default]
+    None,
+    DoNotEdit,
+    WithSourceHash,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Default)]
+pub enum GoIndent {
+    #[default]
+    Tab,
+    Spaces(usize),
+}
+
+impl GoIndent {
+    fn unit(self) -> String {
+        match self {
+            GoIndent::Tab => "\t".to_string(),
+            GoIndent::Spaces(width) => " ".repeat(width),
+        }
+    }
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Default)]
+pub enum AdditionalPropertiesPolicy {
+    #[default]
+    Ignore,
+    CaptureRaw,
+    Error,
+}
+
+impl Default for GoOptions {
+    fn default() -> Self {
+        let mut format_types = HashMap::new();
+        format_types.insert(
+            "date-time".to_string(),
+            GoType::new("time.Time").with_import("time"),
+        );
+        format_types.insert(
+            "date".to_string(),
+            GoType::new("time.Time").with_import("time"),
+        );
+        format_types.insert("byte".to_string(), GoType::new("[]byte"));
+        format_types.insert("binary".to_string(), GoType::new("[]byte"));
+        Self {
+            package_name: "dto".to_string(),
+            format_types,
+            type_overrides: HashMap::new(),
+            tag_naming: TagNamingStrategy::AsIs,
+            optional_strategy: OptionalStrategy::PointerWithOmitempty,
+            emit_enums: false,
+            emit_validation: false,
+            emit_unions: false,
+            emit_union_unmarshal: false,
+            emit_tuples: false,
+            emit_tuple_marshal: false,
+            emit_raw_decoders: false,
+            inline_anonymous: false,
+            emit_constructors: false,
+            emit_getters: false,
+            emit_validate_method: false,
+            additional_properties: AdditionalPropertiesPolicy::Ignore,
+            go_version: None,
+            json_number: false,
+            template: None,
+            header: GoHeader::None,
+            unknown_type: GoType::new("json.RawMessage").with_import("encoding/json"),
+            indent: GoIndent::Tab,
+        }
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct GoType {
+    pub name: String,
+    pub import: Option<String>,
+    pub import_alias: Option<String>,
+}
+
+impl GoType {
+    pub fn new(name: impl Into<String>) -> Self {
+        Self {
+            name: name.into(),
+            import: None,
+            import_alias: None,
+        }
+    }
+
+    pub fn with_import(mut self, import: impl Into<String>) -> Self {
+        self.import = Some(import.into());
+        self
+    }
+
+    pub fn with_import_alias(mut self, alias: impl Into<String>) -> Self {
+        self.import_alias = Some(alias.into());
+        self
+    }
+}
+
 pub fn generate_dto(
     rule: &RuleFile,
     language: DtoLanguage,
     name: Option<&str>,
 ) -> Result<String, DtoError> {
+    generate_dto_with_options(rule, language, name, &DtoOptions::default())
+}
+
+pub fn generate_dto_with_options(
+    rule: &RuleFile,
+    language: DtoLanguage,
+    name: Option<&str>,
+    options: &DtoOptions,
+) -> Result<String, DtoError> {
+    generate_dto_with_warnings(rule, language, name, options).map(|(output, _)| output)
+}
+
+pub fn generate_dto_with_warnings(
+    rule: &RuleFile,
+    language: DtoLanguage,
+    name: Option<&str>,
+    options: &DtoOptions,
+) -> Result<(String, Vec<DtoWarning>), DtoError> {
     let name = name.unwrap_or("Record");
     let schema = build_schema(rule)?;
+    render_schema(schema, TypeRoot::Named(name), language, options)
+}
+
+pub fn generate_dto_from_openapi(
+    source: &str,
+    language: DtoLanguage,
+    options: &DtoOptions,
+) -> Result<String, DtoError> {
+    generate_dto_from_openapi_with_warnings(source, language, options).map(|(output, _)| output)
+}
+
+pub fn generate_dto_from_openapi_with_warnings(
+    source: &str,
+    language: DtoLanguage,
+    options: &DtoOptions,
+) -> Result<(String, Vec<DtoWarning>), DtoError> {
+    let schema = build_openapi_schema(source)?;
+    render_schema(schema, TypeRoot::Components, language, options)
+}
+
+pub fn generate_dto_from_json_schema(
+    path: &Path,
+    language: DtoLanguage,
+    name: Option<&str>,
+    options: &DtoOptions,
+) -> Result<String, DtoError> {
+    generate_dto_from_json_schema_with_warnings(path, language, name, options)
+        .map(|(output, _)| output)
+}
+
+pub fn generate_dto_from_json_schema_with_warnings(
+    path: &Path,
+    language: DtoLanguage,
+    name: Option<&str>,
+    options: &DtoOptions,
+) -> Result<(String, Vec<DtoWarning>), DtoError> {
+    let schema = build_json_schema(path, name)?;
+    render_schema(schema, TypeRoot::Components, language, options)
+}
+
+pub fn build_dto_schema(rule: &RuleFile, name: Option<&str>) -> Result<DtoSchema, DtoError> {
+    Ok(DtoSchema {
+        root: DtoRoot::Named(name.unwrap_or("Record").to_string()),
+        schema: build_schema(rule)?,
+    })
+}
+
+pub fn build_dto_schema_from_openapi(source: &str) -> Result<DtoSchema, DtoError> {
+    Ok(DtoSchema {
+        root: DtoRoot::Components,
+        schema: build_openapi_schema(source)?,
+    })
+}
+
+pub fn build_dto_schema_from_json_schema(
+    path: &Path,
+    name: Option<&str>,
+) -> Result<DtoSchema, DtoError> {
+    Ok(DtoSchema {
+        root: DtoRoot::Components,
+        schema: build_json_schema(path, name)?,
+    })
+}
+
+pub fn generate_dto_from_schema(
+    schema: &DtoSchema,
+    language: DtoLanguage,
+    options: &DtoOptions,
+) -> Result<String, DtoError> {
+    generate_dto_from_schema_with_warnings(schema, language, options).map(|(output, _)| output)
+}
+
+pub fn generate_dto_from_schema_with_warnings(
+    schema: &DtoSchema,
+    language: DtoLanguage,
+    options: &DtoOptions,
+) -> Result<(String, Vec<DtoWarning>), DtoError> {
+    render_schema(schema.schema.clone(), schema.root.as_type_root(), language, options)
+}
+
+pub(crate) fn render_schema(
+    mut schema: SchemaNode,
+    root: TypeRoot,
+    language: DtoLanguage,
+    options: &DtoOptions,
+) -> Result<(String, Vec<DtoWarning>), DtoError> {
+    if let Some(error) = schema_errors(&schema, root).into_iter().next() {
+        return Err(error);
+    }
+    if !matches!(language, DtoLanguage::Go | DtoLanguage::JsonSchema) {
+        let components = schema.clone();
+        inline_embeds(&mut schema, &components, |_| true);
+    } else if language == DtoLanguage::Go
+        && options.go.additional_properties == AdditionalPropertiesPolicy::CaptureRaw
+    {
+        let components = schema.clone();
+        inline_embeds(&mut schema, &components, |node| node.additional_properties);
+    }
+    if options.field_order == FieldOrder::Alphabetical {
+        sort_fields(&mut schema, root);
+    }
+
+    let warnings = fallback_warnings(&schema, root, language, options);
+    if options.strict {
+        if let Some(warning) = warnings.first() {
+            return Err(DtoError::new(warning.message.clone()).with_field(&warning.path));
+        }
+    }
+
+    let merge = options.merge_identical_types;
+    let output = match language {
+        DtoLanguage::Rust => render_rust(&schema, root, merge),
+        DtoLanguage::TypeScript => render_typescript(&schema, root, merge),
+        DtoLanguage::Python => render_python(&schema, root, merge),
+        DtoLanguage::Pydantic => render_pydantic(&schema, root, merge),
+        DtoLanguage::Go => render_go(&schema, root, merge, &options.go),
+        DtoLanguage::Java => render_java(&schema, root, merge),
+        DtoLanguage::Kotlin => render_kotlin(&schema, root, merge),
+        DtoLanguage::Swift => render_swift(&schema, root, merge),
+        DtoLanguage::Protobuf => render_protobuf(&schema, root, merge),
+        DtoLanguage::JsonSchema => render_json_schema(&schema, root),
+    }?;
+    Ok((output, warnings))
+}
+
+fn inline_embeds(
+    node: &mut SchemaNode,
+    components: &SchemaNode,
+    inline: fn(&SchemaNode) -> bool,
+) {
+    if !node.embeds.is_empty() {
+        let mut fields = Vec::new();
+        let mut visited = HashSet::new();
+        for embed in std::mem::take(&mut node.embeds) {
+            let target = components
+                .fields
+                .iter()
+                .find(|field| field.key == embed)
+                .and_then(|field| nested_node(&field.field_type));
+            if target.map_or(true, inline) {
+                collect_embedded_fields(components, &embed, &mut visited, &mut fields);
+            } else {
+                node.embeds.push(embed);
+            }
+        }
+        fields.retain(|field| node.fields.iter().all(|own| own.key != field.key));
+        fields.append(&mut node.fields);
+        node.fields = fields;
+    }
+    for field in &mut node.fields {
+        if let Some(child) = nested_node_mut(&mut field.field_type) {
+            inline_embeds(child, components, inline);
+        }
+    }
+}
+
+fn collect_embedded_fields(
+    components: &SchemaNode,
+    name: &str,
+    visited: &mut HashSet<String>,
+    out: &mut Vec<Field>,
+) {
+    if !visited.insert(name.to_string()) {
+        return;
+    }
+    let Some(node) = components
+        .fields
+        .iter()
+        .find(|field| field.key == name)
+        .and_then(|field| nested_node(&field.field_type))
+    else {
+        return;
+    };
+    for embed in &node.embeds {
+        collect_embedded_fields(components, embed, visited, out);
+    }
+    for field in &node.fields {
+        if out.iter().all(|existing| existing.key != field.key) {
+            out.push(field.clone());
+        }
+    }
+}
+
+fn fallback_warnings(
+    schema: &SchemaNode,
+    root: TypeRoot,
+    language: DtoLanguage,
+    options: &DtoOptions,
+) -> Vec<DtoWarning> {
+    let mut warnings = Vec::new();
+    match root {
+        TypeRoot::Named(name) => {
+            collect_fallback_warnings(schema, name, language, options, &mut warnings);
+        }
+        TypeRoot::Components => {
+            for field in &schema.fields {
+                if let Some(node) = nested_node(&field.field_type) {
+                    collect_fallback_warnings(node, &field.key, language, options, &mut warnings);
+                }
+            }
+        }
+    }
+    warnings
+}
+
+fn collect_fallback_warnings(
+    node: &SchemaNode,
+    prefix: &str,
+    language: DtoLanguage,
+    options: &DtoOptions,
+    warnings: &mut Vec<DtoWarning>,
+) {
+    for field in &node.fields {
+        let path = format!("{}.{}", prefix, field.key);
+        if let Some(reason) = fallback_reason(&field.field_type, language, options) {
+            warnings.push(DtoWarning {
+                path: path.clone(),
+                message: format!(
+                    "{}, fell back to {}",
+                    reason,
+                    json_value_type(language, options)
+                ),
+            });
+        }
+        if let Some(child) = nested_node(&field.field_type) {
+            collect_fallback_warnings(child, &path, language, options, warnings);
+        }
+    }
+}
+
+fn fallback_reason(
+    field_type: &FieldType,
+    language: DtoLanguage,
+    options: &DtoOptions,
+) -> Option<&'static str> {
+    match field_type {
+        FieldType::JsonValue => Some("type could not be inferred"),
+        FieldType::Union(_) => match language {
+            DtoLanguage::JsonSchema => None,
+            DtoLanguage::Go if options.go.emit_unions => None,
+            _ => Some("oneOf union is not supported"),
+        },
+        FieldType::Tuple(_) => match language {
+            DtoLanguage::JsonSchema => None,
+            DtoLanguage::Go if options.go.emit_tuples => None,
+            _ => Some("tuple is not supported"),
+        },
+        FieldType::Array(item) | FieldType::Map(item) => {
+            fallback_reason(item, language, options)
+        }
+        _ => None,
+    }
+}
 
+fn json_value_type(language: DtoLanguage, options: &DtoOptions) -> &str {
     match language {
-        DtoLanguage::Rust => render_rust(&schema, name),
-        DtoLanguage::TypeScript => render_typescript(&schema, name),
-        DtoLanguage::Python => render_python(&schema, name),
-        DtoLanguage::Go => render_go(&schema, name),
-        DtoLanguage::Java => render_java(&schema, name),
-        DtoLanguage::Kotlin => render_kotlin(&schema, name),
-        DtoLanguage::Swift => render_swift(&schema, name),
+        DtoLanguage::Rust => "serde_json::Value",
+        DtoLanguage::TypeScript => "unknown",
+        DtoLanguage::Python | DtoLanguage::Pydantic => "Any",
+        DtoLanguage::Go => &options.go.unknown_type.name,
+        DtoLanguage::Java | DtoLanguage::Kotlin => "JsonNode",
+        DtoLanguage::Swift => "JSONValue",
+        DtoLanguage::Protobuf => "google.protobuf.Value",
+        DtoLanguage::JsonSchema => "{}",
+    }
+}
+
+#[derive(Clone, Copy)]
+pub(crate) enum TypeRoot<'a> {
+    Named(&'a str),
+    Components,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(deny_unknown_fields)]
+pub struct DtoSchema {
+    pub root: DtoRoot,
+    pub schema: SchemaNode,
+}
+
+#[derive(Debug, Clone, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "snake_case")]
+pub enum DtoRoot {
+    Named(String),
+    Components,
+}
+
+impl DtoRoot {
+    pub(crate) fn as_type_root(&self) -> TypeRoot<'_> {
+        match self {
+            DtoRoot::Named(name) => TypeRoot::Named(name),
+            DtoRoot::Components => TypeRoot::Components,
+        }
     }
 }
 
-#[derive(Clone)]
-struct SchemaNode {
-    fields: Vec<Field>,
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(deny_unknown_fields)]
+pub struct SchemaNode {
+    pub fields: Vec<Field>,
+    pub doc: Option<String>,
+    pub additional_properties: bool,
+    #[serde(default)]
+    pub embeds: Vec<String>,
 }
 
-#[derive(Clone)]
-struct Field {
-    key: String,
-    field_type: FieldType,
-    optional: bool,
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(deny_unknown_fields)]
+pub struct Field {
+    pub key: String,
+    #[serde(rename = "type")]
+    pub field_type: FieldType,
+    pub optional: bool,
+    #[serde(default)]
+    pub nullable: bool,
+    #[serde(default)]
+    pub always_emit: bool,
+    #[serde(default, skip_serializing_if = "std::ops::Not::not")]
+    pub deprecated: bool,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub deprecation_message: Option<String>,
+    pub format: Option<String>,
+    #[serde(rename = "enum")]
+    pub enum_values: Option<Vec<EnumValue>>,
+    pub doc: Option<String>,
+    pub constraints: FieldConstraints,
+    pub default: Option<JsonValue>,
+}
+
+#[derive(Debug, Clone, Default, PartialEq, Serialize, Deserialize)]
+#[serde(deny_unknown_fields)]
+pub struct FieldConstraints {
+    pub min: Option<f64>,
+    pub max: Option<f64>,
+    pub min_length: Option<u64>,
+    pub max_length: Option<u64>,
 }
 
-#[derive(Clone)]
-enum FieldType {
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "snake_case")]
+pub enum FieldType {
     Primitive(PrimitiveType),
     Object(Box<SchemaNode>),
+    Array(Box<FieldType>),
+    Map(Box<FieldType>),
+    Ref(String),
+    Union(Box<UnionType>),
+    Tuple(Box<TupleType>),
     JsonValue,
 }
 
-#[derive(Clone, Copy)]
-enum PrimitiveType {
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(deny_unknown_fields)]
+pub struct UnionType {
+    pub name: Option<String>,
+    pub discriminator: Option<String>,
+    pub variants: Vec<UnionVariant>,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(deny_unknown_fields)]
+pub struct UnionVariant {
+    pub tag: String,
+    pub type_name: String,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(deny_unknown_fields)]
+pub struct TupleType {
+    pub name: Option<String>,
+    pub items: Vec<FieldType>,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Serialize, Deserialize)]
+#[serde(rename_all = "lowercase")]
+pub enum PrimitiveType {
     String,
     Int,
     Float,
     Bool,
 }
 
-fn build_schema(rule: &RuleFile) -> Result<SchemaNode, DtoError> {
-    let mut root = SchemaNode { fields: Vec::new() };
+pub(crate) fn build_schema(rule: &RuleFile) -> Result<SchemaNode, DtoError> {
+    let mut root = SchemaNode {
+        fields: Vec::new(),
+        doc: rule
+            .output
+            .as_ref()
+            .and_then(|output| output.description.clone()),
+        additional_properties: false,
+        embeds: Vec::new(),
+    };
 
     for mapping in &rule.mappings {
-        let tokens = parse_path(&mapping.target)
-            .map_err(|_| DtoError::new("target path is invalid"))?;
-        if tokens.iter().any(|token| matches!(token, PathToken::Index(_))) {
-            return Err(DtoError::new("target path must not include indexes"));
-        }
+        insert_mapping(&mut root, mapping).map_err(|err| err.with_field(&mapping.target))?;
+    }
 
-        let mut keys = Vec::new();
-        for token in tokens {
-            match token {
-                PathToken::Key(key) => keys.push(key),
-                PathToken::Index(_) => {}
-            }
-        }
+    Ok(root)
+}
+
+fn insert_mapping(root: &mut SchemaNode, mapping: &Mapping) -> Result<(), DtoError> {
+    let tokens = parse_path(&mapping.target)
+        .map_err(|_| DtoError::new("target path is invalid"))?;
+    if tokens.iter().any(|token| matches!(token, PathToken::Index(_))) {
+        return Err(DtoError::new("target path must not include indexes"));
+    }
 
-        if keys.is_empty() {
-            return Err(DtoError::new("target path is invalid"));
+    let mut keys = Vec::new();
+    for token in tokens {
+        match token {
+            PathToken::Key(key) => keys.push(key),
+            PathToken::Index(_) => {}
         }
+    }
 
-        let field_type = match mapping.value_type.as_deref() {
-            Some("string") => FieldType::Primitive(PrimitiveType::String),
-            Some("int") => FieldType::Primitive(PrimitiveType::Int),
-            Some("float") => FieldType::Primitive(PrimitiveType::Float),
-            Some("bool") => FieldType::Primitive(PrimitiveType::Bool),
-            Some(_) => return Err(DtoError::new("unsupported type in mapping")),
-            None => FieldType::JsonValue,
-        };
-        let conditional = match &mapping.when {
-            None => false,
-            Some(Expr::Literal(JsonValue::Bool(true))) => false,
-            _ => true,
-        };
-        let optional = conditional
-            || !(mapping.required || mapping.value.is_some() || mapping.default.is_some());
+    if keys.is_empty() {
+        return Err(DtoError::new("target path is invalid"));
+    }
+
+    let field_type = scalar_field_type(mapping.value_type.as_deref())?;
+    let field_type = match &mapping.dto {
+        Some(hint) => hint_field_type(hint, field_type)?,
+        None => field_type,
+    };
+    let conditional = match &mapping.when {
+        None => false,
+        Some(Expr::Literal(JsonValue::Bool(true))) => false,
+        _ => true,
+    };
+    let optional = conditional
+        || !(mapping.required || mapping.value.is_some() || mapping.default.is_some());
+    let leaf = Field {
+        default: mapping.default.clone(),
+        ..hinted_field(String::new(), field_type, optional, mapping.dto.as_ref())
+    };
+    insert_field(root, &keys, leaf)
+}
+
+fn scalar_field_type(value_type: Option<&str>) -> Result<FieldType, DtoError> {
+    match value_type {
+        Some("string") => Ok(FieldType::Primitive(PrimitiveType::String)),
+        Some("int") => Ok(FieldType::Primitive(PrimitiveType::Int)),
+        Some("float") => Ok(FieldType::Primitive(PrimitiveType::Float)),
+        Some("bool") => Ok(FieldType::Primitive(PrimitiveType::Bool)),
+        Some(other) => Err(DtoError::new(format!("unsupported type in mapping: {}", other))),
+        None => Ok(FieldType::JsonValue),
+    }
+}
 
-        insert_field(&mut root, &keys, field_type, optional)?;
+fn hint_field_type(hint: &DtoHint, scalar: FieldType) -> Result<FieldType, DtoError> {
+    let shapes =
+        hint.items.is_some() as u8 + hint.values.is_some() as u8 + hint.fields.is_some() as u8;
+    if shapes > 1 {
+        return Err(DtoError::new(
+            "dto items, values, and fields are mutually exclusive",
+        ));
     }
 
-    Ok(root)
+    if let Some(items) = &hint.items {
+        let item_scalar = scalar_field_type(items.value_type.as_deref())?;
+        let item_type = hint_field_type(items, item_scalar)?;
+        return Ok(FieldType::Array(Box::new(item_type)));
+    }
+    if let Some(values) = &hint.values {
+        let value_scalar = scalar_field_type(values.value_type.as_deref())?;
+        let value_type = hint_field_type(values, value_scalar)?;
+        return Ok(FieldType::Map(Box::new(value_type)));
+    }
+    if let Some(fields) = &hint.fields {
+        return Ok(FieldType::Object(Box::new(build_hint_node(fields)?)));
+    }
+    Ok(scalar)
 }
 
-fn insert_field(
-    node: &mut SchemaNode,
-    keys: &[String],
+fn build_hint_node(fields: &[DtoField]) -> Result<SchemaNode, DtoError> {
+    let mut node = SchemaNode {
+        fields: Vec::new(),
+        doc: None,
+        additional_properties: false,
+        embeds: Vec::new(),
+    };
+    for dto_field in fields {
+        if node.fields.iter().any(|field| field.key == dto_field.name) {
+            return Err(DtoError::new("duplicate field in dto"));
+        }
+        let field_type = scalar_field_type(dto_field.value_type.as_deref())
+            .and_then(|field_type| match &dto_field.dto {
+                Some(hint) => hint_field_type(hint, field_type),
+                None => Ok(field_type),
+            })
+            .map_err(|err| err.with_field(&dto_field.name))?;
+        node.fields.push(hinted_field(
+            dto_field.name.clone(),
+            field_type,
+            !dto_field.required,
+            dto_field.dto.as_ref(),
+        ));
+    }
+    Ok(node)
+}
+
+fn hinted_field(
+    key: String,
     field_type: FieldType,
     optional: bool,
-) -> Result<(), DtoError> {
+    hint: Option<&DtoHint>,
+) -> Field {
+    Field {
+        key,
+        field_type,
+        optional,
+        nullable: false,
+        always_emit: hint.map(|hint| hint.always_emit).unwrap_or(false),
+        deprecated: hint
+            .map(|hint| hint.deprecated || hint.deprecation_message.is_some())
+            .unwrap_or(false),
+        deprecation_message: hint.and_then(|hint| hint.deprecation_message.clone()),
+        format: hint.and_then(|hint| hint.format.clone()),
+        enum_values: hint.and_then(|hint| hint.enum_values.clone()),
+        doc: hint.and_then(|hint| hint.description.clone()),
+        constraints: hint
+            .map(|hint| FieldConstraints {
+                min: hint.min,
+                max: hint.max,
+                min_length: hint.min_length,
+                max_length: hint.max_length,
+            })
+            .unwrap_or_default(),
+        default: None,
+    }
+}
+
+fn insert_field(node: &mut SchemaNode, keys: &[String], leaf: Field) -> Result<(), DtoError> {
     if keys.is_empty() {
         return Err(DtoError::new("target path is invalid"));
     }
@@ -144,31 +801,79 @@ fn insert_field(
         }
         node.fields.push(Field {
             key: key.clone(),
-            field_type,
-            optional,
+            ..leaf
         });
         return Ok(());
     }
 
     if let Some(field) = node.fields.iter_mut().find(|field| field.key == *key) {
         match &mut field.field_type {
-            FieldType::Object(child) => {
-                return insert_field(child, &keys[1..], field_type, optional)
-            }
+            FieldType::Object(child) => return insert_field(child, &keys[1..], leaf),
             _ => return Err(DtoError::new("target conflicts with non-object")),
         }
     }
 
-    let mut child = SchemaNode { fields: Vec::new() };
-    insert_field(&mut child, &keys[1..], field_type, optional)?;
+    let mut child = SchemaNode {
+        fields: Vec::new(),
+        doc: None,
+        additional_properties: false,
+        embeds: Vec::new(),
+    };
+    insert_field(&mut child, &keys[1..], leaf)?;
     node.fields.push(Field {
         key: key.clone(),
         field_type: FieldType::Object(Box::new(child)),
         optional: false,
+        nullable: false,
+        always_emit: false,
+        deprecated: false,
+        deprecation_message: None,
+        format: None,
+        enum_values: None,
+        doc: None,
+        constraints: FieldConstraints::default(),
+        default: None,
     });
     Ok(())
 }
 
+fn sort_fields(node: &mut SchemaNode, root: TypeRoot) {
+    if let TypeRoot::Components = root {
+        for field in &mut node.fields {
+            if let Some(child) = nested_node_mut(&mut field.field_type) {
+                sort_node_fields(child);
+            }
+        }
+        return;
+    }
+    sort_node_fields(node);
+}
+
+fn sort_node_fields(node: &mut SchemaNode) {
+    node.fields.sort_by(|a, b| a.key.cmp(&b.key));
+    for field in &mut node.fields {
+        if let Some(child) = nested_node_mut(&mut field.field_type) {
+            sort_node_fields(child);
+        }
+    }
+}
+
+fn nested_node(field_type: &FieldType) -> Option<&SchemaNode> {
+    match field_type {
+        FieldType::Object(child) => Some(child),
+        FieldType::Array(item) | FieldType::Map(item) => nested_node(item),
+        _ => None,
+    }
+}
+
+fn nested_node_mut(field_type: &mut FieldType) -> Option<&mut SchemaNode> {
+    match field_type {
+        FieldType::Object(child) => Some(child),
+        FieldType::Array(item) | FieldType::Map(item) => nested_node_mut(item),
+        _ => None,
+    }
+}
+
 fn node_has_required(node: &SchemaNode) -> bool {
     for field in &node.fields {
         match &field.field_type {
@@ -178,7 +883,7 @@ fn node_has_required(node: &SchemaNode) -> bool {
                 }
             }
             _ => {
-                if !field.optional {
+                if !field.optional && !field.nullable {
                     return true;
                 }
             }
@@ -187,21 +892,53 @@ fn node_has_required(node: &SchemaNode) -> bool {
     false
 }
 
+fn deprecation_message(field: &Field) -> Option<&str> {
+    field
+        .deprecated
+        .then(|| field.deprecation_message.as_deref().unwrap_or("Do not use."))
+}
+
 fn node_uses_json(node: &SchemaNode) -> bool {
+    node_contains(node, |field_type| {
+        matches!(
+            field_type,
+            FieldType::JsonValue | FieldType::Union(_) | FieldType::Tuple(_)
+        )
+    })
+}
+
+fn node_uses_array(node: &SchemaNode) -> bool {
+    node_contains(node, |field_type| matches!(field_type, FieldType::Array(_)))
+}
+
+fn node_uses_map(node: &SchemaNode) -> bool {
+    node_contains(node, |field_type| matches!(field_type, FieldType::Map(_)))
+}
+
+fn node_contains(node: &SchemaNode, predicate: fn(&FieldType) -> bool) -> bool {
     for field in &node.fields {
-        match &field.field_type {
-            FieldType::JsonValue => return true,
-            FieldType::Object(child) => {
-                if node_uses_json(child) {
-                    return true;
-                }
-            }
-            _ => {}
+        if field_type_contains(&field.field_type, predicate) {
+            return true;
         }
     }
     false
 }
 
+fn field_type_contains(field_type: &FieldType, predicate: fn(&FieldType) -> bool) -> bool {
+    if predicate(field_type) {
+        return true;
+    }
+    match field_type {
+        FieldType::Object(child) => node_contains(child, predicate),
+        FieldType::Array(item) | FieldType::Map(item) => field_type_contains(item, predicate),
+        FieldType::Primitive(_)
+        | FieldType::Ref(_)
+        | FieldType::Union(_)
+        | FieldType::Tuple(_)
+        | FieldType::JsonValue => false,
+    }
+}
+
 struct TypeDef<'a> {
     name: String,
     node: &'a SchemaNode,
@@ -210,6 +947,7 @@ struct TypeDef<'a> {
 
 struct NameRegistry {
     base: String,
+    components: bool,
     used: HashSet<String>,
     names: HashMap<Vec<String>, String>,
 }
@@ -218,6 +956,16 @@ impl NameRegistry {
     fn new(base: &str) -> Self {
         Self {
             base: base.to_string(),
+            components: false,
+            used: HashSet::new(),
+            names: HashMap::new(),
+        }
+    }
+
+    fn for_components() -> Self {
+        Self {
+            base: String::new(),
+            components: true,
             used: HashSet::new(),
             names: HashMap::new(),
         }
@@ -229,8 +977,16 @@ impl NameRegistry {
         }
 
         let mut name = self.base.clone();
-        for segment in path {
-            name.push_str(&pascal_case(&words_from_key(segment)));
+        for (index, segment) in path.iter().enumerate() {
+            if segment == ARRAY_ITEM_SEGMENT {
+                name = singular_type_name(&name);
+            } else if segment == MAP_VALUE_SEGMENT {
+                name.push_str("Value");
+            } else if self.components && index == 0 {
+                name.push_str(&pascal_case(&case_words_from_key(segment)));
+            } else {
+                name.push_str(&pascal_case(&words_from_key(segment)));
+            }
         }
 
         if name.is_empty() {
@@ -253,36 +1009,289 @@ impl NameRegistry {
     }
 }
 
-fn collect_types<'a>(
-    node: &'a SchemaNode,
-    path: Vec<String>,
-    registry: &mut NameRegistry,
-    out: &mut Vec<TypeDef<'a>>,
+const ARRAY_ITEM_SEGMENT: &str = "[]";
+const MAP_VALUE_SEGMENT: &str = "{}";
+
+const SINGULAR_WORDS: &[&str] = &["alias", "atlas", "bias", "canvas", "news", "series", "species"];
+
+fn singular_type_name(name: &str) -> String {
+    let word = name[name.rfind(char::is_uppercase).unwrap_or(0)..].to_ascii_lowercase();
+    let singular = SINGULAR_WORDS.contains(&word.as_str())
+        || ["ss", "us", "is"].iter().any(|suffix| word.ends_with(suffix));
+    if singular {
+        format!("{}Item", name)
+    } else if let Some(stem) = name.strip_suffix("ies") {
+        format!("{}y", stem)
+    } else if let Some(stem) = name.strip_suffix("sses") {
+        format!("{}ss", stem)
+    } else if let Some(stem) = name.strip_suffix('s') {
+        stem.to_string()
+    } else {
+        format!("{}Item", name)
+    }
+}
+
+fn collect_schema_types<'a>(
+    schema: &'a SchemaNode,
+    root: TypeRoot,
+    merge: bool,
+) -> (NameRegistry, Vec<TypeDef<'a>>) {
+    let (mut registry, defs) = collect_root_types(schema, root);
+    if !merge {
+        return (registry, defs);
+    }
+    let nested_depth = match root {
+        TypeRoot::Named(_) => 0,
+        TypeRoot::Components => 1,
+    };
+    let mut kept: Vec<TypeDef<'a>> = Vec::new();
+    for def in defs {
+        let canonical = kept
+            .iter()
+            .filter(|kept| def.path.len() > nested_depth && kept.path.len() > nested_depth)
+            .find(|kept| same_structure(kept.node, def.node))
+            .map(|kept| kept.name.clone());
+        match canonical {
+            Some(name) => {
+                registry.names.insert(def.path, name);
+            }
+            None => kept.push(def),
+        }
+    }
+    (registry, kept)
+}
+
+fn same_structure(left: &SchemaNode, right: &SchemaNode) -> bool {
+    left.additional_properties == right.additional_properties
+        && left.embeds == right.embeds
+        && left.fields.len() == right.fields.len()
+        && left.fields.iter().zip(&right.fields).all(|(left, right)| {
+            left.key == right.key
+                && left.optional == right.optional
+                && left.nullable == right.nullable
+                && left.always_emit == right.always_emit
+                && left.deprecated == right.deprecated
+                && left.format == right.format
+                && left.enum_values == right.enum_values
+                && left.constraints == right.constraints
+                && left.default == right.default
+                && same_field_type(&left.field_type, &right.field_type)
+        })
+}
+
+fn same_field_type(left: &FieldType, right: &FieldType) -> bool {
+    match (left, right) {
+        (FieldType::Object(left), FieldType::Object(right)) => same_structure(left, right),
+        (FieldType::Array(left), FieldType::Array(right))
+        | (FieldType::Map(left), FieldType::Map(right)) => same_field_type(left, right),
+        _ => left == right,
+    }
+}
+
+fn collect_root_types<'a>(
+    schema: &'a SchemaNode,
+    root: TypeRoot,
+) -> (NameRegistry, Vec<TypeDef<'a>>) {
+    let mut defs = Vec::new();
+    match root {
+        TypeRoot::Named(name) => {
+            let mut registry = NameRegistry::new(name);
+            collect_types(schema, Vec::new(), &mut registry, &mut defs);
+            (registry, defs)
+        }
+        TypeRoot::Components => {
+            let mut registry = NameRegistry::for_components();
+            for field in &schema.fields {
+                registry.type_name_for_path(&[field.key.clone()]);
+            }
+            for field in &schema.fields {
+                collect_field_types(
+                    &field.field_type,
+                    vec![field.key.clone()],
+                    &mut registry,
+                    &mut defs,
+                );
+            }
+            (registry, defs)
+        }
+    }
+}
+
+fn collect_types<'a>(
+    node: &'a SchemaNode,
+    path: Vec<String>,
+    registry: &mut NameRegistry,
+    out: &mut Vec<TypeDef<'a>>,
 ) {
     for field in &node.fields {
-        if let FieldType::Object(child) = &field.field_type {
-            let mut child_path = path.clone();
-            child_path.push(field.key.clone());
-            registry.type_name_for_path(&child_path);
-            collect_types(child, child_path, registry, out);
-        }
+        let mut child_path = path.clone();
+        child_path.push(field.key.clone());
+        collect_field_types(&field.field_type, child_path, registry, out);
     }
 
     let name = registry.type_name_for_path(&path);
     out.push(TypeDef { name, node, path });
 }
 
+fn collect_field_types<'a>(
+    field_type: &'a FieldType,
+    path: Vec<String>,
+    registry: &mut NameRegistry,
+    out: &mut Vec<TypeDef<'a>>,
+) {
+    match field_type {
+        FieldType::Object(child) => {
+            registry.type_name_for_path(&path);
+            collect_types(child, path, registry, out);
+        }
+        FieldType::Array(item) => {
+            let mut item_path = path;
+            item_path.push(ARRAY_ITEM_SEGMENT.to_string());
+            collect_field_types(item, item_path, registry, out);
+        }
+        FieldType::Map(value) => {
+            let mut value_path = path;
+            value_path.push(MAP_VALUE_SEGMENT.to_string());
+            collect_field_types(value, value_path, registry, out);
+        }
+        FieldType::Union(union) => {
+            registry.type_name_for_path(&union_path(&path, union));
+        }
+        FieldType::Tuple(tuple) => {
+            registry.type_name_for_path(&tuple_path(&path, tuple));
+        }
+        _ => {}
+    }
+}
+
+pub(crate) fn flatten_schema(schema: &SchemaNode, root: TypeRoot) -> Vec<(String, SchemaNode)> {
+    let (_, defs) = collect_schema_types(schema, root, false);
+    let names: HashMap<Vec<String>, String> = defs
+        .iter()
+        .map(|def| (def.path.clone(), def.name.clone()))
+        .collect();
+    defs.iter()
+        .map(|def| {
+            let fields = def
+                .node
+                .fields
+                .iter()
+                .map(|field| {
+                    let path = field_path(&def.path, &field.key);
+                    let optional = match &field.field_type {
+                        FieldType::Object(child) => !node_has_required(child),
+                        _ => field.optional || field.nullable,
+                    };
+                    Field {
+                        field_type: hoist_objects(&field.field_type, &path, &names),
+                        optional,
+                        ..field.clone()
+                    }
+                })
+                .collect();
+            let node = SchemaNode {
+                fields,
+                doc: def.node.doc.clone(),
+                additional_properties: def.node.additional_properties,
+                embeds: def.node.embeds.clone(),
+            };
+            (def.name.clone(), node)
+        })
+        .collect()
+}
+
+fn hoist_objects(
+    field_type: &FieldType,
+    path: &[String],
+    names: &HashMap<Vec<String>, String>,
+) -> FieldType {
+    match field_type {
+        FieldType::Object(_) => match names.get(path) {
+            Some(name) => FieldType::Ref(name.clone()),
+            None => field_type.clone(),
+        },
+        FieldType::Array(item) => {
+            FieldType::Array(Box::new(hoist_objects(item, &item_path(path), names)))
+        }
+        FieldType::Map(value) => {
+            FieldType::Map(Box::new(hoist_objects(value, &map_value_path(path), names)))
+        }
+        other => other.clone(),
+    }
+}
+
+fn field_path(parent_path: &[String], key: &str) -> Vec<String> {
+    let mut path = parent_path.to_vec();
+    path.push(key.to_string());
+    path
+}
+
+fn item_path(path: &[String]) -> Vec<String> {
+    let mut path = path.to_vec();
+    path.push(ARRAY_ITEM_SEGMENT.to_string());
+    path
+}
+
+fn map_value_path(path: &[String]) -> Vec<String> {
+    let mut path = path.to_vec();
+    path.push(MAP_VALUE_SEGMENT.to_string());
+    path
+}
+
+fn union_path(path: &[String], union: &UnionType) -> Vec<String> {
+    match &union.name {
+        Some(name) => vec![name.clone()],
+        None => path.to_vec(),
+    }
+}
+
+fn tuple_path(path: &[String], tuple: &TupleType) -> Vec<String> {
+    match &tuple.name {
+        Some(name) => vec![name.clone()],
+        None => path.to_vec(),
+    }
+}
+
+fn nested_tuple(field_type: &FieldType, path: Vec<String>) -> Option<(&TupleType, Vec<String>)> {
+    match field_type {
+        FieldType::Tuple(tuple) => Some((tuple, tuple_path(&path, tuple))),
+        FieldType::Array(item) => nested_tuple(item, item_path(&path)),
+        FieldType::Map(value) => nested_tuple(value, map_value_path(&path)),
+        _ => None,
+    }
+}
+
+fn nested_union(field_type: &FieldType, path: Vec<String>) -> Option<(&UnionType, Vec<String>)> {
+    match field_type {
+        FieldType::Union(union) => Some((union, union_path(&path, union))),
+        FieldType::Array(item) => nested_union(item, item_path(&path)),
+        FieldType::Map(value) => nested_union(value, map_value_path(&path)),
+        _ => None,
+    }
+}
+
+fn object_type_name(path: &[String], registry: &NameRegistry) -> String {
+    registry
+        .get(path)
+        .cloned()
+        .unwrap_or_else(|| "Record".to_string())
+}
+
 fn field_identifier(
     lang: DtoLanguage,
     key: &str,
     used: &mut HashMap<String, usize>,
 ) -> String {
     let base = match lang {
-        DtoLanguage::Rust | DtoLanguage::Python => snake_case(&words_from_key(key)),
+        DtoLanguage::Rust
+        | DtoLanguage::Python
+        | DtoLanguage::Pydantic
+        | DtoLanguage::Protobuf => snake_case(&words_from_key(key)),
         DtoLanguage::TypeScript | DtoLanguage::Java | DtoLanguage::Kotlin | DtoLanguage::Swift => {
             lower_camel(&words_from_key(key))
         }
         DtoLanguage::Go => pascal_case(&words_from_key(key)),
+        DtoLanguage::JsonSchema => key.to_string(),
     };
 
     let mut ident = if base.is_empty() {
@@ -306,6 +1315,7 @@ fn field_identifier(
             DtoLanguage::Java | DtoLanguage::Kotlin | DtoLanguage::Swift => {
                 format!("field{}", capitalize(&ident))
             }
+            DtoLanguage::Protobuf => format!("field_{}", ident),
             _ => format!("_{}", ident),
         };
     }
@@ -317,13 +1327,19 @@ fn field_identifier(
         };
     }
 
-    let entry = used.entry(ident.clone()).or_insert(0);
-    if *entry > 0 {
-        *entry += 1;
-        format!("{}_{}", ident, *entry)
-    } else {
-        *entry = 1;
-        ident
+    if !used.contains_key(&ident) {
+        used.insert(ident.clone(), 1);
+        return ident;
+    }
+    let separator = if lang == DtoLanguage::Go { "" } else { "_" };
+    loop {
+        let count = used.entry(ident.clone()).or_insert(1);
+        *count += 1;
+        let candidate = format!("{}{}{}", ident, separator, *count);
+        if !used.contains_key(&candidate) {
+            used.insert(candidate.clone(), 1);
+            return candidate;
+        }
     }
 }
 
@@ -347,6 +1363,35 @@ fn words_from_key(key: &str) -> Vec<String> {
     words
 }
 
+fn case_words_from_key(key: &str) -> Vec<String> {
+    let mut words = Vec::new();
+    for word in words_from_key(key) {
+        let chars: Vec<char> = word.chars().collect();
+        let mut current = String::new();
+        for (index, ch) in chars.iter().enumerate() {
+            if index > 0 && ch.is_ascii_uppercase() {
+                let prev = chars[index - 1];
+                let next_is_lower = chars
+                    .get(index + 1)
+                    .map(|next| next.is_ascii_lowercase())
+                    .unwrap_or(false);
+                let boundary = prev.is_ascii_lowercase()
+                    || prev.is_ascii_digit()
+                    || (prev.is_ascii_uppercase() && next_is_lower);
+                if boundary && !current.is_empty() {
+                    words.push(current);
+                    current = String::new();
+                }
+            }
+            current.push(*ch);
+        }
+        if !current.is_empty() {
+            words.push(current);
+        }
+    }
+    words
+}
+
 fn snake_case(words: &[String]) -> String {
     words
         .iter()
@@ -392,11 +1437,12 @@ fn is_reserved(lang: DtoLanguage, ident: &str) -> bool {
     match lang {
         DtoLanguage::Rust => is_reserved_rust(ident),
         DtoLanguage::TypeScript => is_reserved_typescript(ident),
-        DtoLanguage::Python => is_reserved_python(ident),
+        DtoLanguage::Python | DtoLanguage::Pydantic => is_reserved_python(ident),
         DtoLanguage::Go => is_reserved_go(ident),
         DtoLanguage::Java => is_reserved_java(ident),
         DtoLanguage::Kotlin => is_reserved_kotlin(ident),
         DtoLanguage::Swift => is_reserved_swift(ident),
+        DtoLanguage::Protobuf | DtoLanguage::JsonSchema => false,
     }
 }
 
@@ -713,12 +1759,14 @@ fn is_reserved_swift(value: &str) -> bool {
     )
 }
 
-fn render_rust(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
-    let mut registry = NameRegistry::new(name);
-    let mut defs = Vec::new();
-    collect_types(schema, Vec::new(), &mut registry, &mut defs);
+fn render_rust(schema: &SchemaNode, root: TypeRoot, merge: bool) -> Result<String, DtoError> {
+    let (registry, defs) = collect_schema_types(schema, root, merge);
+    let recursive = recursive_fields(&defs);
 
     let mut out = String::new();
+    if node_uses_map(schema) {
+        out.push_str("use std::collections::HashMap;\n\n");
+    }
     out.push_str("use serde::{Deserialize, Serialize};\n");
     if node_uses_json(schema) {
         out.push_str("use serde_json::Value;\n");
@@ -735,9 +1783,14 @@ fn render_rust(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
             let rename = ident != field.key;
             let optional = match &field.field_type {
                 FieldType::Object(child) => !node_has_required(child),
-                _ => field.optional,
+                _ => field.optional || field.nullable,
             };
             let field_type = rust_type_for_field(field, &def.path, &registry);
+            let field_type = if recursive.contains(&field_path(&def.path, &field.key)) {
+                format!("Box<{}>", field_type)
+            } else {
+                field_type
+            };
 
             let mut attrs = Vec::new();
             if optional {
@@ -748,6 +1801,12 @@ fn render_rust(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
                 attrs.push(format!("rename = \"{}\"", field.key));
             }
 
+            if let Some(message) = deprecation_message(field) {
+                out.push_str(&format!(
+                    "    #[deprecated(note = {})]\n",
+                    rust_string_literal(message)
+                ));
+            }
             if !attrs.is_empty() {
                 out.push_str(&format!("    #[serde({})]\n", attrs.join(", ")));
             }
@@ -766,28 +1825,45 @@ fn render_rust(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
     Ok(out.trim_end().to_string())
 }
 
+fn rust_string_literal(value: &str) -> String {
+    let mut out = String::from("\"");
+    for ch in value.chars() {
+        match ch {
+            '"' => out.push_str("\\\""),
+            '\\' => out.push_str("\\\\"),
+            '\n' => out.push_str("\\n"),
+            '\r' => out.push_str("\\r"),
+            '\t' => out.push_str("\\t"),
+            _ => out.push(ch),
+        }
+    }
+    out.push('"');
+    out
+}
+
 fn rust_type_for_field(field: &Field, parent_path: &[String], registry: &NameRegistry) -> String {
-    match &field.field_type {
+    rust_type(&field.field_type, &field_path(parent_path, &field.key), registry)
+}
+
+fn rust_type(field_type: &FieldType, path: &[String], registry: &NameRegistry) -> String {
+    match field_type {
         FieldType::Primitive(PrimitiveType::String) => "String".to_string(),
         FieldType::Primitive(PrimitiveType::Int) => "i64".to_string(),
         FieldType::Primitive(PrimitiveType::Float) => "f64".to_string(),
         FieldType::Primitive(PrimitiveType::Bool) => "bool".to_string(),
-        FieldType::JsonValue => "Value".to_string(),
-        FieldType::Object(_) => {
-            let mut path = parent_path.to_vec();
-            path.push(field.key.clone());
-            registry
-                .get(&path)
-                .cloned()
-                .unwrap_or_else(|| "Record".to_string())
-        }
+        FieldType::JsonValue | FieldType::Union(_) | FieldType::Tuple(_) => "Value".to_string(),
+        FieldType::Array(item) => format!("Vec<{}>", rust_type(item, &item_path(path), registry)),
+        FieldType::Map(value) => format!(
+            "HashMap<String, {}>",
+            rust_type(value, &map_value_path(path), registry)
+        ),
+        FieldType::Object(_) => object_type_name(path, registry),
+        FieldType::Ref(name) => object_type_name(&[name.clone()], registry),
     }
 }
 
-fn render_typescript(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
-    let mut registry = NameRegistry::new(name);
-    let mut defs = Vec::new();
-    collect_types(schema, Vec::new(), &mut registry, &mut defs);
+fn render_typescript(schema: &SchemaNode, root: TypeRoot, merge: bool) -> Result<String, DtoError> {
+    let (registry, defs) = collect_schema_types(schema, root, merge);
 
     let mut out = String::new();
     for def in defs {
@@ -798,12 +1874,17 @@ fn render_typescript(schema: &SchemaNode, name: &str) -> Result<String, DtoError
             let rename = ident != field.key;
             let optional = match &field.field_type {
                 FieldType::Object(child) => !node_has_required(child),
-                _ => field.optional,
+                _ => field.optional || field.nullable,
             };
             let field_type = typescript_type_for_field(field, &def.path, &registry);
+            let mut jsdoc = Vec::new();
             if rename {
-                out.push_str(&format!("  /** json: \"{}\" */\n", field.key));
+                jsdoc.push(format!("json: \"{}\"", field.key));
             }
+            if let Some(message) = deprecation_message(field) {
+                jsdoc.push(format!("@deprecated {}", message));
+            }
+            out.push_str(&typescript_jsdoc(&jsdoc));
             let suffix = if optional { "?" } else { "" };
             out.push_str(&format!("  {}{}: {};\n", ident, suffix, field_type));
         }
@@ -813,45 +1894,73 @@ fn render_typescript(schema: &SchemaNode, name: &str) -> Result<String, DtoError
     Ok(out.trim_end().to_string())
 }
 
+fn typescript_jsdoc(lines: &[String]) -> String {
+    let lines: Vec<&str> = lines.iter().flat_map(|line| line.lines()).collect();
+    match lines.as_slice() {
+        [] => String::new(),
+        [line] => format!("  /** {} */\n", line),
+        lines => {
+            let mut out = String::from("  /**\n");
+            for line in lines {
+                if line.is_empty() {
+                    out.push_str("   *\n");
+                } else {
+                    out.push_str(&format!("   * {}\n", line));
+                }
+            }
+            out.push_str("   */\n");
+            out
+        }
+    }
+}
+
 fn typescript_type_for_field(
     field: &Field,
     parent_path: &[String],
     registry: &NameRegistry,
 ) -> String {
-    match &field.field_type {
+    typescript_type(&field.field_type, &field_path(parent_path, &field.key), registry)
+}
+
+fn typescript_type(field_type: &FieldType, path: &[String], registry: &NameRegistry) -> String {
+    match field_type {
         FieldType::Primitive(PrimitiveType::String) => "string".to_string(),
         FieldType::Primitive(PrimitiveType::Int) => "number".to_string(),
         FieldType::Primitive(PrimitiveType::Float) => "number".to_string(),
         FieldType::Primitive(PrimitiveType::Bool) => "boolean".to_string(),
-        FieldType::JsonValue => "unknown".to_string(),
-        FieldType::Object(_) => {
-            let mut path = parent_path.to_vec();
-            path.push(field.key.clone());
-            registry
-                .get(&path)
-                .cloned()
-                .unwrap_or_else(|| "Record".to_string())
+        FieldType::JsonValue | FieldType::Union(_) | FieldType::Tuple(_) => "unknown".to_string(),
+        FieldType::Array(item) => {
+            format!("{}[]", typescript_type(item, &item_path(path), registry))
         }
+        FieldType::Map(value) => format!(
+            "{{ [key: string]: {} }}",
+            typescript_type(value, &map_value_path(path), registry)
+        ),
+        FieldType::Object(_) => object_type_name(path, registry),
+        FieldType::Ref(name) => object_type_name(&[name.clone()], registry),
     }
 }
 
-fn render_python(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
-    let mut registry = NameRegistry::new(name);
-    let mut defs = Vec::new();
-    collect_types(schema, Vec::new(), &mut registry, &mut defs);
+fn render_python(schema: &SchemaNode, root: TypeRoot, merge: bool) -> Result<String, DtoError> {
+    let (registry, defs) = collect_schema_types(schema, root, merge);
 
     let uses_json = node_uses_json(schema);
-    let uses_optional = schema_has_optional(schema);
-    let uses_rename = schema_has_rename(schema, DtoLanguage::Python);
+    let uses_optional = defs_have_optional(&defs);
+    let uses_rename = defs_have_rename(&defs, DtoLanguage::Python);
+    let uses_array = node_uses_array(schema);
+    let uses_map = node_uses_map(schema);
 
     let mut out = String::new();
+    if defs_have_forward_refs(&defs) {
+        out.push_str("from __future__ import annotations\n\n");
+    }
     out.push_str("from dataclasses import dataclass");
     if uses_rename {
         out.push_str(", field");
     }
     out.push('\n');
 
-    if uses_json || uses_optional {
+    if uses_json || uses_optional || uses_array || uses_map {
         let mut parts = Vec::new();
         if uses_optional {
             parts.push("Optional");
@@ -859,6 +1968,12 @@ fn render_python(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
         if uses_json {
             parts.push("Any");
         }
+        if uses_array {
+            parts.push("List");
+        }
+        if uses_map {
+            parts.push("Dict");
+        }
         out.push_str(&format!("from typing import {}\n", parts.join(", ")));
     }
     out.push('\n');
@@ -877,6 +1992,7 @@ fn render_python(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
             field_type: String,
             optional: bool,
             rename: bool,
+            deprecated: Option<String>,
         }
 
         let mut used = HashMap::new();
@@ -886,7 +2002,7 @@ fn render_python(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
             let rename = ident != field.key;
             let optional = match &field.field_type {
                 FieldType::Object(child) => !node_has_required(child),
-                _ => field.optional,
+                _ => field.optional || field.nullable,
             };
             let field_type = python_type_for_field(field, &def.path, &registry, optional);
             fields.push(RenderField {
@@ -895,6 +2011,7 @@ fn render_python(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
                 field_type,
                 optional,
                 rename,
+                deprecated: deprecation_message(field).map(str::to_string),
             });
         }
 
@@ -903,20 +2020,30 @@ fn render_python(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
             .filter(|field| !field.optional)
             .chain(fields.iter().filter(|field| field.optional))
         {
+            if let Some(message) = &field.deprecated {
+                for line in format!("Deprecated: {}", message).lines() {
+                    out.push_str(format!("    # {}", line).trim_end());
+                    out.push('\n');
+                }
+            }
             if field.rename {
-                out.push_str(&format!("    # json: \"{}\"\n", field.key));
+                out.push_str(&format!("    # json: {}\n", python_string_literal(&field.key)));
             }
 
             if field.rename {
                 if field.optional {
                     out.push_str(&format!(
-                        "    {}: {} = field(default=None, metadata={{\"json_key\": \"{}\"}})\n",
-                        field.ident, field.field_type, field.key
+                        "    {}: {} = field(default=None, metadata={{\"json_key\": {}}})\n",
+                        field.ident,
+                        field.field_type,
+                        python_string_literal(&field.key)
                     ));
                 } else {
                     out.push_str(&format!(
-                        "    {}: {} = field(metadata={{\"json_key\": \"{}\"}})\n",
-                        field.ident, field.field_type, field.key
+                        "    {}: {} = field(metadata={{\"json_key\": {}}})\n",
+                        field.ident,
+                        field.field_type,
+                        python_string_literal(&field.key)
                     ));
                 }
             } else if field.optional {
@@ -931,108 +2058,1602 @@ fn render_python(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
     Ok(out.trim_end().to_string())
 }
 
+fn render_pydantic(schema: &SchemaNode, root: TypeRoot, merge: bool) -> Result<String, DtoError> {
+    let (registry, defs) = collect_schema_types(schema, root, merge);
+
+    let uses_json = node_uses_json(schema);
+    let uses_optional = defs_have_optional(&defs);
+    let uses_field = defs_have_rename(&defs, DtoLanguage::Pydantic) || defs_have_deprecated(&defs);
+    let uses_array = node_uses_array(schema);
+    let uses_map = node_uses_map(schema);
+
+    let mut out = String::new();
+    if defs_have_forward_refs(&defs) {
+        out.push_str("from __future__ import annotations\n\n");
+    }
+    if uses_json || uses_optional || uses_array || uses_map {
+        let mut parts = Vec::new();
+        if uses_optional {
+            parts.push("Optional");
+        }
+        if uses_json {
+            parts.push("Any");
+        }
+        if uses_array {
+            parts.push("List");
+        }
+        if uses_map {
+            parts.push("Dict");
+        }
+        out.push_str(&format!("from typing import {}\n\n", parts.join(", ")));
+    }
+    out.push_str("from pydantic import BaseModel");
+    if uses_field {
+        out.push_str(", Field");
+    }
+    out.push_str("\n\n");
+
+    for def in defs {
+        out.push_str(&format!("class {}(BaseModel):\n", def.name));
+        if def.node.fields.is_empty() {
+            out.push_str("    pass\n\n");
+            continue;
+        }
+
+        let mut used = HashMap::new();
+        for field in &def.node.fields {
+            let ident = field_identifier(DtoLanguage::Pydantic, &field.key, &mut used);
+            let optional = match &field.field_type {
+                FieldType::Object(child) => !node_has_required(child),
+                _ => field.optional || field.nullable,
+            };
+            let field_type = python_type_for_field(field, &def.path, &registry, optional);
+            let rename = ident != field.key;
+            let default = match (&field.default, optional) {
+                (Some(value), _) => Some(python_literal(value)),
+                (None, true) => Some("None".to_string()),
+                (None, false) => None,
+            };
+            let deprecated = deprecation_message(field);
+            let default = if rename || deprecated.is_some() {
+                let mut args = Vec::new();
+                if let Some(default) = default {
+                    args.push(format!("default={}", default));
+                }
+                if rename {
+                    args.push(format!("alias={}", python_string_literal(&field.key)));
+                }
+                if let Some(message) = deprecated {
+                    args.push(format!("deprecated={}", python_string_literal(message)));
+                }
+                format!(" = Field({})", args.join(", "))
+            } else {
+                default.map(|default| format!(" = {}", default)).unwrap_or_default()
+            };
+            out.push_str(&format!("    {}: {}{}\n", ident, field_type, default));
+        }
+        out.push('\n');
+    }
+
+    Ok(out.trim_end().to_string())
+}
+
+fn python_literal(value: &JsonValue) -> String {
+    match value {
+        JsonValue::Null => "None".to_string(),
+        JsonValue::Bool(true) => "True".to_string(),
+        JsonValue::Bool(false) => "False".to_string(),
+        JsonValue::Number(number) => number.to_string(),
+        JsonValue::String(value) => python_string_literal(value),
+        JsonValue::Array(items) => {
+            let items: Vec<String> = items.iter().map(python_literal).collect();
+            format!("[{}]", items.join(", "))
+        }
+        JsonValue::Object(entries) => {
+            let entries: Vec<String> = entries
+                .iter()
+                .map(|(key, value)| {
+                    format!("{}: {}", python_string_literal(key), python_literal(value))
+                })
+                .collect();
+            format!("{{{}}}", entries.join(", "))
+        }
+    }
+}
+
+fn python_string_literal(value: &str) -> String {
+    let mut out = String::from("\"");
+    for ch in value.chars() {
+        match ch {
+            '"' => out.push_str("\\\""),
+            '\\' => out.push_str("\\\\"),
+            '\n' => out.push_str("\\n"),
+            '\r' => out.push_str("\\r"),
+            '\t' => out.push_str("\\t"),
+            _ => out.push(ch),
+        }
+    }
+    out.push('"');
+    out
+}
+
 fn python_type_for_field(
     field: &Field,
     parent_path: &[String],
     registry: &NameRegistry,
     optional: bool,
 ) -> String {
-    let base = match &field.field_type {
+    let base = python_type(&field.field_type, &field_path(parent_path, &field.key), registry);
+
+    if optional {
+        format!("Optional[{}]", base)
+    } else {
+        base
+    }
+}
+
+fn python_type(field_type: &FieldType, path: &[String], registry: &NameRegistry) -> String {
+    match field_type {
         FieldType::Primitive(PrimitiveType::String) => "str".to_string(),
         FieldType::Primitive(PrimitiveType::Int) => "int".to_string(),
         FieldType::Primitive(PrimitiveType::Float) => "float".to_string(),
         FieldType::Primitive(PrimitiveType::Bool) => "bool".to_string(),
-        FieldType::JsonValue => "Any".to_string(),
-        FieldType::Object(_) => {
-            let mut path = parent_path.to_vec();
+        FieldType::JsonValue | FieldType::Union(_) | FieldType::Tuple(_) => "Any".to_string(),
+        FieldType::Array(item) => {
+            format!("List[{}]", python_type(item, &item_path(path), registry))
+        }
+        FieldType::Map(value) => format!(
+            "Dict[str, {}]",
+            python_type(value, &map_value_path(path), registry)
+        ),
+        FieldType::Object(_) => object_type_name(path, registry),
+        FieldType::Ref(name) => object_type_name(&[name.clone()], registry),
+    }
+}
+
+fn render_go(
+    schema: &SchemaNode,
+    root: TypeRoot,
+    merge: bool,
+    options: &GoOptions,
+) -> Result<String, DtoError> {
+    if !is_go_package_name(&options.package_name) {
+        return Err(DtoError::new(format!(
+            "invalid Go package name: {}",
+            options.package_name
+        )));
+    }
+
+    let omitzero = match &options.go_version {
+        Some(version) => go_version_at_least(version, 24)
+            .ok_or_else(|| DtoError::new(format!("invalid Go version: {}", version)))?,
+        None => false,
+    };
+
+    let (mut registry, defs) = collect_schema_types(schema, root, merge);
+    let recursive = recursive_fields(&defs);
+    let override_paths = go_override_paths(&defs);
+
+    let mut enum_names = HashMap::new();
+    if options.emit_enums {
+        for def in &defs {
+            for field in &def.node.fields {
+                if go_enum_values(field).is_some() {
+                    let mut path = def.path.clone();
+                    path.push(field.key.clone());
+                    let enum_name = registry.type_name_for_path(&path);
+                    enum_names.insert(path, enum_name);
+                }
+            }
+        }
+    }
+
+    let fields = GoFields {
+        registry: &registry,
+        recursive: &recursive,
+        enum_names: &enum_names,
+        override_paths: &override_paths,
+        omitzero,
+        options,
+    };
+    let inline_depth = match root {
+        TypeRoot::Named(_) => 0,
+        TypeRoot::Components => 1,
+    };
+    let mut imports = GoImports::default();
+    let mut body = String::new();
+    let mut declarations = String::new();
+    let mut structs = Vec::new();
+    let mut emitted_unions = HashSet::new();
+    let mut emitted_tuples = HashSet::new();
+    for def in &defs {
+        let declarations_start = body.len();
+        for field in &def.node.fields {
+            let mut path = def.path.clone();
             path.push(field.key.clone());
-            registry
-                .get(&path)
-                .cloned()
-                .unwrap_or_else(|| "Record".to_string())
+            if let (Some(enum_name), Some(values)) =
+                (enum_names.get(&path), go_enum_values(field))
+            {
+                let base = match field.field_type {
+                    FieldType::Primitive(PrimitiveType::Int) => {
+                        go_int_type(field.format.as_deref())
+                    }
+                    _ => "string",
+                };
+                let code = render_go_enum(enum_name, base, values)
+                    .map_err(|err| err.with_field(&go_error_path(root, &path)))?;
+                body.push_str(&code);
+            }
+            if options.emit_tuples {
+                if let Some((tuple, tuple_path)) = nested_tuple(&field.field_type, path.clone()) {
+                    let tuple_name = object_type_name(&tuple_path, &registry);
+                    if emitted_tuples.insert(tuple_name.clone()) {
+                        body.push_str(&render_go_tuple(
+                            &tuple_name,
+                            tuple,
+                            &tuple_path,
+                            &registry,
+                            options,
+                            &mut imports,
+                        ));
+                    }
+                }
+            }
+            if !options.emit_unions {
+                continue;
+            }
+            if let Some((union, union_path)) = nested_union(&field.field_type, path) {
+                let union_name = object_type_name(&union_path, &registry);
+                if emitted_unions.insert(union_name.clone()) {
+                    body.push_str(&render_go_union(
+                        &union_name,
+                        union,
+                        &registry,
+                        options,
+                        &mut imports,
+                    ));
+                }
+            }
+        }
+
+        declarations.push_str(&body[declarations_start..]);
+        if options.inline_anonymous && def.path.len() > inline_depth {
+            continue;
+        }
+        let capture_extra = def.node.additional_properties
+            && match options.additional_properties {
+                AdditionalPropertiesPolicy::Ignore => false,
+                AdditionalPropertiesPolicy::CaptureRaw => true,
+                AdditionalPropertiesPolicy::Error => {
+                    return Err(DtoError::new("additionalProperties is not supported")
+                        .with_field(&go_error_path(root, &def.path)));
+                }
+            };
+
+        if let Some(doc) = &def.node.doc {
+            body.push_str(&go_doc_comment(doc, ""));
+        }
+        body.push_str(&format!("type {} struct {{\n", def.name));
+        let mut rendered = Vec::new();
+        body.push_str(&fields.render(def.node, &def.path, 1, &mut imports, &mut rendered));
+        let extra_ident = if rendered.iter().any(|field| field.ident == "Extra") {
+            "AdditionalProperties"
+        } else {
+            "Extra"
+        };
+        let mut template_fields: Vec<JsonValue> = rendered
+            .iter()
+            .map(|rendered| {
+                serde_json::json!({
+                    "name": rendered.ident,
+                    "key": rendered.field.key,
+                    "go_type": rendered.field_type,
+                    "tag": rendered.tag,
+                    "optional": rendered.field.optional || rendered.field.nullable,
+                    "doc": rendered.field.doc,
+                    "doc_lines": go_template_doc_lines(rendered.field.doc.as_deref()),
+                    "deprecated": deprecation_message(rendered.field),
+                })
+            })
+            .collect();
+        if capture_extra {
+            imports.insert("encoding/json");
+            body.push_str(&format!(
+                "\t{}\tmap[string]json.RawMessage\t`json:\"-\"`\n",
+                extra_ident
+            ));
+            template_fields.push(serde_json::json!({
+                "name": extra_ident,
+                "key": null,
+                "go_type": "map[string]json.RawMessage",
+                "tag": "`json:\"-\"`",
+                "optional": false,
+                "doc": null,
+                "doc_lines": [],
+                "deprecated": null,
+            }));
+        }
+        body.push_str("}\n\n");
+        let methods_start = body.len();
+
+        if capture_extra {
+            let mut embedded = Vec::new();
+            for embed in &def.node.embeds {
+                collect_embedded_fields(schema, embed, &mut HashSet::new(), &mut embedded);
+            }
+            let keys: Vec<String> = embedded
+                .iter()
+                .chain(rendered.iter().map(|field| field.field))
+                .map(|field| go_tag_name(&field.key, options.tag_naming))
+                .collect();
+            let keys: Vec<&str> = keys.iter().map(String::as_str).collect();
+            body.push_str(&render_go_extra_marshal(&def.name, extra_ident, &keys));
+        }
+
+        if options.emit_constructors {
+            body.push_str(&render_go_constructor(&def.name, &rendered, options));
+        }
+        if options.emit_getters {
+            body.push_str(&render_go_getters(&def.name, &rendered));
+        }
+        if options.emit_validate_method {
+            body.push_str(&render_go_validate(&def.name, &rendered, &mut imports));
+        }
+        if options.emit_raw_decoders {
+            for field in &rendered {
+                if field.field_type.trim_start_matches('*') == "json.RawMessage" {
+                    body.push_str(&render_go_raw_decoder(
+                        &def.name,
+                        &field.ident,
+                        field.field_type.starts_with('*'),
+                    ));
+                }
+            }
+        }
+        let embeds: Vec<String> = def
+            .node
+            .embeds
+            .iter()
+            .map(|embed| object_type_name(&[embed.clone()], &registry))
+            .collect();
+        structs.push(serde_json::json!({
+            "name": def.name,
+            "doc": def.node.doc,
+            "doc_lines": go_template_doc_lines(def.node.doc.as_deref()),
+            "embeds": embeds,
+            "fields": template_fields,
+            "methods": body[methods_start..].trim_end(),
+        }));
+    }
+
+    let header = go_header(schema, root, options.header);
+    if let Some(template) = &options.template {
+        let generated_at = Utc::now().to_rfc3339_opts(SecondsFormat::Secs, true);
+        let context = serde_json::json!({
+            "header": header.trim_end(),
+            "generated_at": generated_at,
+            "package": options.package_name,
+            "imports": imports.template_context(),
+            "declarations": declarations.trim_end(),
+            "structs": structs,
+        });
+        return Ok(render_template(template, &context)?.trim_end().to_string());
+    }
+
+    let mut out = header;
+    out.push_str(&format!("package {}\n\n", options.package_name));
+    out.push_str(&imports.render());
+    out.push_str(&body);
+
+    Ok(align_go_columns(out.trim_end(), options.indent))
+}
+
+fn go_header(schema: &SchemaNode, root: TypeRoot, header: GoHeader) -> String {
+    let mut out = match header {
+        GoHeader::None => return String::new(),
+        GoHeader::DoNotEdit | GoHeader::WithSourceHash => {
+            "// Code generated by transform-rules; DO NOT EDIT.\n".to_string()
         }
     };
+    if header == GoHeader::WithSourceHash {
+        out.push_str(&format!("// Source hash: fnv1a64:{}\n", schema_hash(schema, root)));
+    }
+    out.push('\n');
+    out
+}
 
-    if optional {
-        format!("Optional[{}]", base)
-    } else {
-        base
+fn schema_hash(schema: &SchemaNode, root: TypeRoot) -> String {
+    let root = match root {
+        TypeRoot::Named(name) => name,
+        TypeRoot::Components => "",
+    };
+    let schema = serde_json::to_string(schema).unwrap_or_default();
+    let mut hash: u64 = 0xcbf2_9ce4_8422_2325;
+    for byte in root.bytes().chain([0]).chain(schema.bytes()) {
+        hash ^= u64::from(byte);
+        hash = hash.wrapping_mul(0x0100_0000_01b3);
     }
+    format!("{:016x}", hash)
 }
 
-fn render_go(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
-    let mut registry = NameRegistry::new(name);
-    let mut defs = Vec::new();
-    collect_types(schema, Vec::new(), &mut registry, &mut defs);
+#[derive(Default)]
+struct GoImports {
+    paths: BTreeMap<String, Option<String>>,
+}
 
-    let uses_json = node_uses_json(schema);
+impl GoImports {
+    fn insert(&mut self, path: &str) {
+        self.paths.entry(path.to_string()).or_insert(None);
+    }
 
-    let mut out = String::new();
-    out.push_str("package dto\n\n");
-    if uses_json {
-        out.push_str("import \"encoding/json\"\n\n");
+    fn insert_type(&mut self, go_type: &GoType) {
+        if let Some(path) = &go_type.import {
+            let alias = self.paths.entry(path.clone()).or_insert(None);
+            if alias.is_none() {
+                *alias = go_type.import_alias.clone();
+            }
+        }
+    }
+
+    fn render(&self) -> String {
+        let spec = |(path, alias): (&String, &Option<String>)| match alias {
+            Some(alias) => format!("{} \"{}\"", alias, path),
+            None => format!("\"{}\"", path),
+        };
+        if let (1, Some(import)) = (self.paths.len(), self.paths.iter().next()) {
+            return format!("import {}\n\n", spec(import));
+        }
+        let (std, third_party): (Vec<_>, Vec<_>) = self
+            .paths
+            .iter()
+            .partition(|(path, _)| is_go_std_import(path));
+        let groups: Vec<String> = [std, third_party]
+            .into_iter()
+            .filter(|group| !group.is_empty())
+            .map(|group| {
+                group
+                    .into_iter()
+                    .map(|import| format!("\t{}\n", spec(import)))
+                    .collect()
+            })
+            .collect();
+        if groups.is_empty() {
+            return String::new();
+        }
+        format!("import (\n{})\n\n", groups.join("\n"))
+    }
+
+    fn template_context(&self) -> Vec<JsonValue> {
+        let mut imports: Vec<(&String, &Option<String>)> = self.paths.iter().collect();
+        imports.sort_by_key(|(path, _)| !is_go_std_import(path));
+        imports
+            .into_iter()
+            .map(|(path, alias)| {
+                serde_json::json!({
+                    "path": path,
+                    "alias": alias,
+                    "std": is_go_std_import(path),
+                })
+            })
+            .collect()
+    }
+}
+
+fn go_template_doc_lines(doc: Option<&str>) -> Vec<&str> {
+    doc.map(|doc| doc.trim_end().lines().map(str::trim_end).collect())
+        .unwrap_or_default()
+}
+
+fn is_go_std_import(path: &str) -> bool {
+    !path.split('/').next().unwrap_or("").contains('.')
+}
+
+struct GoRenderedField<'a> {
+    ident: String,
+    field_type: String,
+    tag: String,
+    field: &'a Field,
+}
+
+struct GoFields<'a> {
+    registry: &'a NameRegistry,
+    recursive: &'a HashSet<Vec<String>>,
+    enum_names: &'a HashMap<Vec<String>, String>,
+    override_paths: &'a HashMap<Vec<String>, String>,
+    omitzero: bool,
+    options: &'a GoOptions,
+}
+
+impl GoFields<'_> {
+    fn render<'n>(
+        &self,
+        node: &'n SchemaNode,
+        parent_path: &[String],
+        depth: usize,
+        imports: &mut GoImports,
+        rendered: &mut Vec<GoRenderedField<'n>>,
+    ) -> String {
+        let indent = "\t".repeat(depth);
+        let mut out = String::new();
+        let mut used = HashMap::new();
+        for embed in &node.embeds {
+            let type_name = object_type_name(&[embed.clone()], self.registry);
+            out.push_str(&format!("{}{}\n", indent, type_name));
+            used.insert(type_name, 1);
+        }
+        for field in &node.fields {
+            let ident = field_identifier(DtoLanguage::Go, &field.key, &mut used);
+            let strategy = self.options.optional_strategy;
+            let nullable = field.nullable && strategy == OptionalStrategy::PointerForNullable;
+            let optional = match &field.field_type {
+                FieldType::Object(child) => !node_has_required(child),
+                _ => field.optional || (field.nullable && !nullable),
+            };
+            let path = field_path(parent_path, &field.key);
+            let recursive = self.recursive.contains(&path);
+            let always_emit = field.always_emit && !recursive;
+            let nilable_type = go_format_type(field, self.options)
+                .is_some_and(|go_type| go_type.name.starts_with("[]"))
+                || go_unknown_is_nilable(field, self.options);
+            let omitzero = optional
+                && self.omitzero
+                && !recursive
+                && !always_emit
+                && !nullable
+                && !nilable_type
+                && matches!(field.field_type, FieldType::Primitive(_))
+                && strategy != OptionalStrategy::AlwaysPointer;
+            let pointer = recursive
+                || (!omitzero
+                    && !always_emit
+                    && !nilable_type
+                    && go_field_is_pointer(field, optional, nullable, strategy));
+            let field_type = self.field_type(field, parent_path, pointer, depth, imports);
+            let tag_name = go_tag_name(&field.key, self.options.tag_naming);
+            let mut tags = vec![(
+                "json",
+                match (optional && !always_emit, omitzero) {
+                    (true, true) => format!("{},omitzero", tag_name),
+                    (true, false) => format!("{},omitempty", tag_name),
+                    (false, _) => tag_name,
+                },
+            )];
+            if self.options.emit_validation {
+                let optional = optional || nullable;
+                if let Some(rules) = go_validate_rules(field, optional, self.options.json_number) {
+                    tags.push(("validate", rules));
+                }
+            }
+            let tag = go_struct_tag(&tags);
+            if let Some(doc) = &field.doc {
+                out.push_str(&go_doc_comment(doc, &indent));
+            }
+            if let Some(message) = deprecation_message(field) {
+                if field.doc.is_some() {
+                    out.push_str(&format!("{}//\n", indent));
+                }
+                out.push_str(&go_doc_comment(&format!("Deprecated: {}", message), &indent));
+            }
+            let separator = if field_type.contains('\n') { ' ' } else { '\t' };
+            out.push_str(&format!(
+                "{}{}\t{}{}{}\n",
+                indent, ident, field_type, separator, tag
+            ));
+            rendered.push(GoRenderedField {
+                ident,
+                field_type,
+                tag,
+                field,
+            });
+        }
+        out
+    }
+
+    fn field_type(
+        &self,
+        field: &Field,
+        parent_path: &[String],
+        pointer: bool,
+        depth: usize,
+        imports: &mut GoImports,
+    ) -> String {
+        let path = field_path(parent_path, &field.key);
+        let type_override = self
+            .override_paths
+            .get(&path)
+            .and_then(|key| self.options.type_overrides.get(key));
+        if let Some(go_type) = type_override {
+            imports.insert_type(go_type);
+            return if pointer {
+                format!("*{}", go_type.name)
+            } else {
+                go_type.name.clone()
+            };
+        }
+        if let Some(enum_name) = self.enum_names.get(&path) {
+            return if pointer {
+                format!("*{}", enum_name)
+            } else {
+                enum_name.clone()
+            };
+        }
+        if self.options.inline_anonymous {
+            if let Some(inline) = self.inline_type(&field.field_type, &path, depth, imports) {
+                return if pointer {
+                    format!("*{}", inline)
+                } else {
+                    inline
+                };
+            }
+        }
+        go_type_for_field(
+            field,
+            parent_path,
+            self.registry,
+            pointer,
+            self.options,
+            imports,
+        )
+    }
+
+    fn inline_type(
+        &self,
+        field_type: &FieldType,
+        path: &[String],
+        depth: usize,
+        imports: &mut GoImports,
+    ) -> Option<String> {
+        match field_type {
+            FieldType::Object(node) => {
+                let fields = self.render(node, path, depth + 1, imports, &mut Vec::new());
+                Some(format!("struct {{\n{}{}}}", fields, "\t".repeat(depth)))
+            }
+            FieldType::Array(item) => self
+                .inline_type(item, &item_path(path), depth, imports)
+                .map(|item| format!("[]{}", item)),
+            FieldType::Map(value) => self
+                .inline_type(value, &map_value_path(path), depth, imports)
+                .map(|value| format!("map[string]{}", value)),
+            _ => None,
+        }
+    }
+}
+
+fn align_go_columns(source: &str, indent: GoIndent) -> String {
+    let rows: Vec<(usize, Vec<&str>)> = source
+        .lines()
+        .map(|line| {
+            let content = line.trim_start_matches('\t');
+            let indent = line.len() - content.len();
+            if content.starts_with("//") {
+                (indent, vec![content])
+            } else {
+                (indent, content.split('\t').collect())
+            }
+        })
+        .collect();
+
+    let mut widths: Vec<Vec<usize>> = rows.iter().map(|(_, cells)| vec![0; cells.len()]).collect();
+    let columns = rows.iter().map(|(_, cells)| cells.len()).max().unwrap_or(0);
+    for column in 0..columns {
+        let mut start = 0;
+        while start < rows.len() {
+            let (indent, _) = rows[start];
+            let mut end = start;
+            while end < rows.len() && rows[end].0 == indent && rows[end].1.len() > column + 1 {
+                end += 1;
+            }
+            if end == start {
+                start += 1;
+                continue;
+            }
+            let width = rows[start..end]
+                .iter()
+                .map(|(_, cells)| cells[column].chars().count())
+                .max()
+                .unwrap_or(0);
+            for row in start..end {
+                widths[row][column] = width;
+            }
+            start = end;
+        }
+    }
+
+    let unit = indent.unit();
+    let mut out = String::new();
+    for ((depth, cells), widths) in rows.iter().zip(&widths) {
+        out.push_str(&unit.repeat(*depth));
+        for (index, cell) in cells.iter().enumerate() {
+            out.push_str(cell);
+            if index + 1 < cells.len() {
+                let padding = widths[index] + 1 - cell.chars().count();
+                out.push_str(&" ".repeat(padding));
+            }
+        }
+        out.push('\n');
+    }
+    out.trim_end().to_string()
+}
+
+fn go_override_paths(defs: &[TypeDef]) -> HashMap<Vec<String>, String> {
+    let def_names: HashMap<&[String], &str> = defs
+        .iter()
+        .map(|def| (def.path.as_slice(), def.name.as_str()))
+        .collect();
+    let mut idents = HashMap::new();
+    for def in defs {
+        let mut used = HashMap::new();
+        for field in &def.node.fields {
+            let mut path = def.path.clone();
+            path.push(field.key.clone());
+            idents.insert(path, field_identifier(DtoLanguage::Go, &field.key, &mut used));
+        }
+    }
+
+    let mut paths = HashMap::new();
+    for path in idents.keys() {
+        let Some(start) = (0..path.len()).find(|len| def_names.contains_key(&path[..*len])) else {
+            continue;
+        };
+        let mut segments = vec![def_names[&path[..start]].to_string()];
+        for len in start + 1..=path.len() {
+            if let Some(ident) = idents.get(&path[..len]) {
+                segments.push(ident.clone());
+            }
+        }
+        paths.insert(path.clone(), segments.join("."));
+    }
+    paths
+}
+
+fn is_go_package_name(name: &str) -> bool {
+    let mut chars = name.chars();
+    let first_ok = match chars.next() {
+        Some(first) => first.is_alphabetic() || first == '_',
+        None => false,
+    };
+    first_ok
+        && chars.all(|ch| ch.is_alphanumeric() || ch == '_')
+        && name != "_"
+        && !is_reserved_go(name)
+}
+
+fn go_struct_tag(tags: &[(&str, String)]) -> String {
+    let parts: Vec<String> = tags
+        .iter()
+        .map(|(namespace, value)| format!("{}:\"{}\"", namespace, value))
+        .collect();
+    format!("`{}`", parts.join(" "))
+}
+
+fn go_validate_rules(field: &Field, optional: bool, json_number: bool) -> Option<String> {
+    let constraints = &field.constraints;
+    let (min, max) = match &field.field_type {
+        FieldType::Primitive(PrimitiveType::Int) | FieldType::Primitive(PrimitiveType::Float)
+            if !json_number =>
+        {
+            (
+                constraints.min.map(|value| value.to_string()),
+                constraints.max.map(|value| value.to_string()),
+            )
+        }
+        FieldType::Primitive(PrimitiveType::String) | FieldType::Array(_) | FieldType::Map(_) => (
+            constraints.min_length.map(|value| value.to_string()),
+            constraints.max_length.map(|value| value.to_string()),
+        ),
+        _ => (None, None),
+    };
+
+    let mut rules = Vec::new();
+    if let Some(min) = min {
+        rules.push(format!("min={}", min));
+    }
+    if let Some(max) = max {
+        rules.push(format!("max={}", max));
+    }
+    if !optional {
+        rules.insert(0, "required".to_string());
+    } else if !rules.is_empty() {
+        rules.insert(0, "omitempty".to_string());
+    }
+
+    if rules.is_empty() {
+        None
+    } else {
+        Some(rules.join(","))
+    }
+}
+
+fn go_doc_comment(doc: &str, indent: &str) -> String {
+    let mut out = String::new();
+    for line in doc.trim_end().lines() {
+        let line = line.trim_end();
+        if line.is_empty() {
+            out.push_str(&format!("{}//\n", indent));
+        } else {
+            out.push_str(&format!("{}// {}\n", indent, line));
+        }
+    }
+    out
+}
+
+fn go_error_path(root: TypeRoot, path: &[String]) -> String {
+    let mut full = match root {
+        TypeRoot::Named(name) => vec![name.to_string()],
+        TypeRoot::Components => Vec::new(),
+    };
+    full.extend(path.iter().cloned());
+    full.join(".")
+}
+
+fn go_enum_values(field: &Field) -> Option<&Vec<EnumValue>> {
+    match (&field.field_type, &field.enum_values) {
+        (FieldType::Primitive(PrimitiveType::String | PrimitiveType::Int), Some(values))
+            if !values.is_empty() =>
+        {
+            Some(values)
+        }
+        _ => None,
+    }
+}
+
+fn go_enum_constant(type_name: &str, value: &EnumValue) -> String {
+    match (value.name(), value.literal()) {
+        (Some(name), _) => name.to_string(),
+        (None, EnumLiteral::String(value)) => {
+            format!("{}{}", type_name, pascal_case(&words_from_key(value)))
+        }
+        (None, EnumLiteral::Int(value)) if *value < 0 => {
+            format!("{}Minus{}", type_name, value.unsigned_abs())
+        }
+        (None, EnumLiteral::Int(value)) => format!("{}{}", type_name, value),
+    }
+}
+
+fn render_go_enum(name: &str, base: &str, values: &[EnumValue]) -> Result<String, DtoError> {
+    let mut out = String::new();
+    out.push_str(&format!("type {} {}\n\n", name, base));
+    out.push_str("const (\n");
+    let mut constants = HashSet::new();
+    for value in values {
+        let constant = go_enum_constant(name, value);
+        if !constants.insert(constant.clone()) {
+            return Err(DtoError::new(format!(
+                "enum constant {} is defined more than once",
+                constant
+            )));
+        }
+        let literal = match value.literal() {
+            EnumLiteral::Int(value) => value.to_string(),
+            EnumLiteral::String(value) => go_string_literal(value),
+        };
+        out.push_str(&format!("\t{}\t{}\t= {}\n", constant, name, literal));
+    }
+    out.push_str(")\n\n");
+    Ok(out)
+}
+
+fn render_go_constructor(
+    type_name: &str,
+    fields: &[GoRenderedField],
+    options: &GoOptions,
+) -> String {
+    let mut locals = String::new();
+    let mut values = String::new();
+    for rendered in fields {
+        let Some(default) = &rendered.field.default else {
+            continue;
+        };
+        let base = rendered.field_type.trim_start_matches('*');
+        let enum_values = go_enum_values(rendered.field).filter(|_| options.emit_enums);
+        let literal = match enum_values {
+            Some(values) if base != "string" => values
+                .iter()
+                .find(|value| match value.literal() {
+                    EnumLiteral::Int(value) => default.as_i64() == Some(*value),
+                    EnumLiteral::String(value) => default.as_str() == Some(value),
+                })
+                .map(|value| go_enum_constant(base, value)),
+            _ => go_default_literal(base, default),
+        };
+        let Some(literal) = literal else {
+            continue;
+        };
+        let value = if rendered.field_type.starts_with('*') {
+            let local = format!("default{}", rendered.ident);
+            let typed = if go_numeric_type(base) {
+                format!("{}({})", base, literal)
+            } else {
+                literal
+            };
+            locals.push_str(&format!("\t{} := {}\n", local, typed));
+            format!("&{}", local)
+        } else {
+            literal
+        };
+        values.push_str(&format!("\t\t{}:\t{},\n", rendered.ident, value));
+    }
+
+    let mut out = format!("func New{}() *{} {{\n", type_name, type_name);
+    out.push_str(&locals);
+    if values.is_empty() {
+        out.push_str(&format!("\treturn &{}{{}}\n", type_name));
+    } else {
+        out.push_str(&format!("\treturn &{}{{\n", type_name));
+        out.push_str(&values);
+        out.push_str("\t}\n");
+    }
+    out.push_str("}\n\n");
+    out
+}
+
+fn go_default_literal(go_type: &str, value: &JsonValue) -> Option<String> {
+    if let Some(item_type) = go_type.strip_prefix("[]") {
+        let items = value
+            .as_array()?
+            .iter()
+            .map(|item| go_default_literal(item_type, item))
+            .collect::<Option<Vec<_>>>()?;
+        return Some(format!("{}{{{}}}", go_type, items.join(", ")));
+    }
+    if let Some(value_type) = go_type.strip_prefix("map[string]") {
+        let entries = value
+            .as_object()?
+            .iter()
+            .map(|(key, value)| {
+                go_default_literal(value_type, value)
+                    .map(|value| format!("{}: {}", go_string_literal(key), value))
+            })
+            .collect::<Option<Vec<_>>>()?;
+        return Some(format!("{}{{{}}}", go_type, entries.join(", ")));
+    }
+    match (go_type, value) {
+        ("string", JsonValue::String(value)) => Some(go_string_literal(value)),
+        ("bool", JsonValue::Bool(value)) => Some(value.to_string()),
+        ("float32" | "float64", JsonValue::Number(number)) => Some(number.to_string()),
+        ("int" | "int8" | "int16" | "int32" | "int64", JsonValue::Number(number)) => {
+            number.as_i64().map(|value| value.to_string())
+        }
+        ("uint" | "uint8" | "uint16" | "uint32" | "uint64", JsonValue::Number(number)) => {
+            number.as_u64().map(|value| value.to_string())
+        }
+        ("json.Number", JsonValue::Number(number)) => Some(format!(
+            "json.Number({})",
+            go_string_literal(&number.to_string())
+        )),
+        ("json.RawMessage", value) => Some(format!(
+            "json.RawMessage({})",
+            go_string_literal(&value.to_string())
+        )),
+        _ => None,
+    }
+}
+
+fn go_numeric_type(go_type: &str) -> bool {
+    matches!(
+        go_type,
+        "int"
+            | "int8"
+            | "int16"
+            | "int32"
+            | "int64"
+            | "uint"
+            | "uint8"
+            | "uint16"
+            | "uint32"
+            | "uint64"
+            | "float32"
+            | "float64"
+    )
+}
+
+fn go_receiver(type_name: &str) -> String {
+    type_name
+        .chars()
+        .next()
+        .map(|first| first.to_lowercase().collect::<String>())
+        .unwrap_or_else(|| "r".to_string())
+}
+
+fn render_go_getters(type_name: &str, fields: &[GoRenderedField]) -> String {
+    let receiver = go_receiver(type_name);
+    let idents: HashSet<&str> = fields.iter().map(|field| field.ident.as_str()).collect();
+    let mut out = String::new();
+    for field in fields {
+        let Some(base) = field.field_type.strip_prefix('*') else {
+            continue;
+        };
+        let getter = format!("Get{}", field.ident);
+        if base.contains('\n') || idents.contains(getter.as_str()) {
+            continue;
+        }
+        out.push_str(&format!(
+            "func ({} {}) {}() {} {{\n",
+            receiver, type_name, getter, base
+        ));
+        out.push_str(&format!("\tif {}.{} != nil {{\n", receiver, field.ident));
+        out.push_str(&format!("\t\treturn *{}.{}\n", receiver, field.ident));
+        out.push_str("\t}\n");
+        match go_zero_literal(base) {
+            Some(zero) => out.push_str(&format!("\treturn {}\n", zero)),
+            None => {
+                out.push_str(&format!("\tvar zero {}\n", base));
+                out.push_str("\treturn zero\n");
+            }
+        }
+        out.push_str("}\n\n");
+    }
+    out
+}
+
+fn render_go_validate(
+    type_name: &str,
+    fields: &[GoRenderedField],
+    imports: &mut GoImports,
+) -> String {
+    let receiver = go_receiver(type_name);
+    let mut checks = String::new();
+    for rendered in fields {
+        let field = rendered.field;
+        let pointer = rendered.field_type.starts_with('*');
+        let base = rendered.field_type.trim_start_matches('*');
+        let access = format!("{}.{}", receiver, rendered.ident);
+        let key = &field.key;
+        let mut push_check = |condition: String, message: String| {
+            checks.push_str(&format!("\tif {} {{\n", condition));
+            checks.push_str(&format!(
+                "\t\terrs = append(errs, errors.New({}))\n",
+                go_string_literal(&message)
+            ));
+            checks.push_str("\t}\n");
+        };
+        if pointer && !field.optional && !field.nullable {
+            push_check(format!("{} == nil", access), format!("{} is required", key));
+        }
+        let (guard, value) = if pointer {
+            (Some(format!("{} != nil", access)), format!("*{}", access))
+        } else if field.optional || field.nullable {
+            let guard = go_zero_literal(base).map(|zero| format!("{} != {}", access, zero));
+            (guard, access.clone())
+        } else {
+            (None, access.clone())
+        };
+        let guarded = |condition: String| match &guard {
+            Some(guard) => format!("{} && {}", guard, condition),
+            None => condition,
+        };
+
+        let constraints = &field.constraints;
+        let length = match base {
+            "string" => Some(format!("utf8.RuneCountInString({})", value)),
+            base if base.starts_with("[]") || base.starts_with("map[") => {
+                Some(format!("len({})", value))
+            }
+            _ => None,
+        };
+        if let Some(length) = length {
+            if base == "string"
+                && (constraints.min_length.is_some() || constraints.max_length.is_some())
+            {
+                imports.insert("unicode/utf8");
+            }
+            if let Some(min) = constraints.min_length {
+                push_check(
+                    guarded(format!("{} < {}", length, min)),
+                    format!("{} length must be at least {}", key, min),
+                );
+            }
+            if let Some(max) = constraints.max_length {
+                push_check(
+                    guarded(format!("{} > {}", length, max)),
+                    format!("{} length must be at most {}", key, max),
+                );
+            }
+        } else if go_numeric_type(base) {
+            let integer = !base.starts_with("float");
+            let bound = |value: f64, round: fn(f64) -> f64| {
+                if integer { round(value) } else { value }.to_string()
+            };
+            if let Some(min) = constraints.min {
+                push_check(
+                    guarded(format!("{} < {}", value, bound(min, f64::ceil))),
+                    format!("{} must be at least {}", key, min),
+                );
+            }
+            if let Some(max) = constraints.max {
+                push_check(
+                    guarded(format!("{} > {}", value, bound(max, f64::floor))),
+                    format!("{} must be at most {}", key, max),
+                );
+            }
+        }
+    }
+    if checks.is_empty() {
+        return String::new();
+    }
+
+    imports.insert("errors");
+    let mut out = format!("func ({} {}) Validate() error {{\n", receiver, type_name);
+    out.push_str("\tvar errs []error\n");
+    out.push_str(&checks);
+    out.push_str("\treturn errors.Join(errs...)\n");
+    out.push_str("}\n\n");
+    out
+}
+
+fn go_zero_literal(go_type: &str) -> Option<&'static str> {
+    match go_type {
+        "string" | "json.Number" => Some("\"\""),
+        "bool" => Some("false"),
+        "json.RawMessage" | "any" | "interface{}" => Some("nil"),
+        go_type if go_numeric_type(go_type) => Some("0"),
+        go_type if go_type.starts_with("[]") || go_type.starts_with("map[") => Some("nil"),
+        _ => None,
+    }
+}
+
+fn render_go_raw_decoder(type_name: &str, ident: &str, pointer: bool) -> String {
+    let receiver = go_receiver(type_name);
+    let mut out = format!(
+        "func ({} *{}) Decode{}(v any) error {{\n",
+        receiver, type_name, ident
+    );
+    if pointer {
+        out.push_str(&format!("\tif {}.{} == nil {{\n", receiver, ident));
+        out.push_str("\t\treturn nil\n");
+        out.push_str("\t}\n");
+        out.push_str(&format!("\treturn json.Unmarshal(*{}.{}, v)\n", receiver, ident));
+    } else {
+        out.push_str(&format!("\treturn json.Unmarshal({}.{}, v)\n", receiver, ident));
+    }
+    out.push_str("}\n\n");
+    out
+}
+
+fn render_go_union(
+    name: &str,
+    union: &UnionType,
+    registry: &NameRegistry,
+    options: &GoOptions,
+    imports: &mut GoImports,
+) -> String {
+    let mut used = HashMap::new();
+    let discriminator = union.discriminator.as_ref().map(|key| {
+        (
+            field_identifier(DtoLanguage::Go, key, &mut used),
+            go_tag_name(key, options.tag_naming),
+        )
+    });
+    let variants: Vec<(&UnionVariant, String)> = union
+        .variants
+        .iter()
+        .map(|variant| {
+            let type_name = object_type_name(&[variant.type_name.clone()], registry);
+            (variant, type_name)
+        })
+        .collect();
+
+    let mut out = format!("type {} struct {{\n", name);
+    if let Some((ident, tag)) = &discriminator {
+        out.push_str(&format!("\t{}\tstring\t`json:\"{}\"`\n", ident, tag));
+    }
+    for (_, type_name) in &variants {
+        out.push_str(&format!("\t{}\t*{}\t`json:\"-\"`\n", type_name, type_name));
+    }
+    out.push_str("}\n\n");
+
+    if !options.emit_union_unmarshal {
+        return out;
+    }
+    imports.insert("encoding/json");
+    let receiver = go_receiver(name);
+    match &discriminator {
+        Some((ident, tag)) => {
+            out.push_str(&render_go_union_marshal(name, &receiver, tag, &variants));
+            out.push_str(&format!(
+                "func ({} *{}) UnmarshalJSON(data []byte) error {{\n",
+                receiver, name
+            ));
+            out.push_str(&format!("\t*{} = {}{{}}\n", receiver, name));
+            out.push_str("\tif string(data) == \"null\" {\n\t\treturn nil\n\t}\n");
+            out.push_str("\tvar probe struct {\n");
+            out.push_str(&format!("\t\t{}\tstring\t`json:\"{}\"`\n", ident, tag));
+            out.push_str("\t}\n");
+            out.push_str("\tif err := json.Unmarshal(data, &probe); err != nil {\n");
+            out.push_str("\t\treturn err\n");
+            out.push_str("\t}\n");
+            out.push_str(&format!("\t{}.{} = probe.{}\n", receiver, ident, ident));
+            out.push_str(&format!("\tswitch probe.{} {{\n", ident));
+            for (variant, type_name) in &variants {
+                out.push_str(&format!("\tcase {}:\n", go_string_literal(&variant.tag)));
+                out.push_str(&format!("\t\t{}.{} = new({})\n", receiver, type_name, type_name));
+                out.push_str(&format!(
+                    "\t\treturn json.Unmarshal(data, {}.{})\n",
+                    receiver, type_name
+                ));
+            }
+            out.push_str("\t}\n");
+            out.push_str("\treturn nil\n");
+            out.push_str("}\n\n");
+        }
+        None => {
+            imports.insert("bytes");
+            imports.insert("errors");
+            out.push_str(&format!(
+                "func ({} {}) MarshalJSON() ([]byte, error) {{\n",
+                receiver, name
+            ));
+            out.push_str("\tswitch {\n");
+            for (_, type_name) in &variants {
+                out.push_str(&format!("\tcase {}.{} != nil:\n", receiver, type_name));
+                out.push_str(&format!("\t\treturn json.Marshal({}.{})\n", receiver, type_name));
+            }
+            out.push_str("\t}\n");
+            out.push_str("\treturn []byte(\"null\"), nil\n");
+            out.push_str("}\n\n");
+            out.push_str(&format!(
+                "func ({} *{}) UnmarshalJSON(data []byte) error {{\n",
+                receiver, name
+            ));
+            out.push_str(&format!("\t*{} = {}{{}}\n", receiver, name));
+            out.push_str("\tif string(data) == \"null\" {\n\t\treturn nil\n\t}\n");
+            out.push_str("\tdecode := func(variant any) bool {\n");
+            out.push_str("\t\tdecoder := json.NewDecoder(bytes.NewReader(data))\n");
+            out.push_str("\t\tdecoder.DisallowUnknownFields()\n");
+            out.push_str("\t\treturn decoder.Decode(variant) == nil\n");
+            out.push_str("\t}\n");
+            for (_, type_name) in &variants {
+                out.push_str(&format!(
+                    "\tif variant := new({}); decode(variant) {{\n",
+                    type_name
+                ));
+                out.push_str(&format!("\t\t{}.{} = variant\n", receiver, type_name));
+                out.push_str("\t\treturn nil\n");
+                out.push_str("\t}\n");
+            }
+            out.push_str(&format!(
+                "\treturn errors.New({})\n",
+                go_string_literal(&format!("no {} variant matches", name))
+            ));
+            out.push_str("}\n\n");
+        }
+    }
+    out
+}
+
+fn render_go_union_marshal(
+    name: &str,
+    receiver: &str,
+    tag: &str,
+    variants: &[(&UnionVariant, String)],
+) -> String {
+    let mut out = format!(
+        "func ({} {}) MarshalJSON() ([]byte, error) {{\n",
+        receiver, name
+    );
+    out.push_str("\tvar (\n\t\tvariant\tany\n\t\ttag\tjson.RawMessage\n\t)\n");
+    out.push_str("\tswitch {\n");
+    for (variant, type_name) in variants {
+        let literal = JsonValue::from(variant.tag.as_str()).to_string();
+        let literal = if literal.contains('`') {
+            go_string_literal(&literal)
+        } else {
+            format!("`{}`", literal)
+        };
+        out.push_str(&format!("\tcase {}.{} != nil:\n", receiver, type_name));
+        out.push_str(&format!(
+            "\t\tvariant, tag = {}.{}, json.RawMessage({})\n",
+            receiver, type_name, literal
+        ));
+    }
+    out.push_str("\tdefault:\n\t\treturn []byte(\"null\"), nil\n\t}\n");
+    out.push_str("\tdata, err := json.Marshal(variant)\n");
+    out.push_str("\tif err != nil {\n\t\treturn nil, err\n\t}\n");
+    out.push_str("\tvar fields map[string]json.RawMessage\n");
+    out.push_str("\tif err := json.Unmarshal(data, &fields); err != nil {\n");
+    out.push_str("\t\treturn nil, err\n\t}\n");
+    out.push_str(&format!("\tfields[{}] = tag\n", go_string_literal(tag)));
+    out.push_str("\treturn json.Marshal(fields)\n");
+    out.push_str("}\n\n");
+    out
+}
+
+fn render_go_tuple(
+    name: &str,
+    tuple: &TupleType,
+    path: &[String],
+    registry: &NameRegistry,
+    options: &GoOptions,
+    imports: &mut GoImports,
+) -> String {
+    let items: Vec<(String, String)> = tuple
+        .items
+        .iter()
+        .enumerate()
+        .map(|(index, item)| {
+            let item_type = go_type(item, path, registry, options, imports);
+            (format!("Item{}", index), item_type)
+        })
+        .collect();
+    let mut out = format!("type {} struct {{\n", name);
+    for (ident, item_type) in &items {
+        out.push_str(&format!("\t{}\t{}\n", ident, item_type));
+    }
+    out.push_str("}\n\n");
+    if !options.emit_tuple_marshal {
+        return out;
+    }
+
+    imports.insert("encoding/json");
+    imports.insert("fmt");
+    let receiver = go_receiver(name);
+    let values: Vec<String> = items
+        .iter()
+        .map(|(ident, _)| format!("{}.{}", receiver, ident))
+        .collect();
+    out.push_str(&format!(
+        "func ({} {}) MarshalJSON() ([]byte, error) {{\n",
+        receiver, name
+    ));
+    out.push_str(&format!("\treturn json.Marshal([]any{{{}}})\n", values.join(", ")));
+    out.push_str("}\n\n");
+    out.push_str(&format!(
+        "func ({} *{}) UnmarshalJSON(data []byte) error {{\n",
+        receiver, name
+    ));
+    out.push_str("\tvar items []json.RawMessage\n");
+    out.push_str("\tif err := json.Unmarshal(data, &items); err != nil {\n");
+    out.push_str("\t\treturn err\n");
+    out.push_str("\t}\n");
+    out.push_str(&format!("\tif len(items) != {} {{\n", items.len()));
+    out.push_str(&format!(
+        "\t\treturn fmt.Errorf(\"expected {} items for {}, got %d\", len(items))\n",
+        items.len(),
+        name
+    ));
+    out.push_str("\t}\n");
+    for (index, value) in values.iter().enumerate() {
+        out.push_str(&format!(
+            "\tif err := json.Unmarshal(items[{}], &{}); err != nil {{\n",
+            index, value
+        ));
+        out.push_str("\t\treturn err\n");
+        out.push_str("\t}\n");
+    }
+    out.push_str("\treturn nil\n");
+    out.push_str("}\n\n");
+    out
+}
+
+fn render_go_extra_marshal(type_name: &str, ident: &str, keys: &[&str]) -> String {
+    let receiver = go_receiver(type_name);
+    let mut out = String::new();
+    out.push_str(&format!(
+        "func ({} {}) MarshalJSON() ([]byte, error) {{\n",
+        receiver, type_name
+    ));
+    out.push_str(&format!("\ttype alias {}\n", type_name));
+    out.push_str(&format!("\tdata, err := json.Marshal(alias({}))\n", receiver));
+    out.push_str("\tif err != nil {\n");
+    out.push_str("\t\treturn nil, err\n");
+    out.push_str("\t}\n");
+    out.push_str(&format!("\tif len({}.{}) == 0 {{\n", receiver, ident));
+    out.push_str("\t\treturn data, nil\n");
+    out.push_str("\t}\n");
+    out.push_str("\tfields := map[string]json.RawMessage{}\n");
+    out.push_str("\tif err := json.Unmarshal(data, &fields); err != nil {\n");
+    out.push_str("\t\treturn nil, err\n");
+    out.push_str("\t}\n");
+    out.push_str(&format!("\tfor key, value := range {}.{} {{\n", receiver, ident));
+    out.push_str("\t\tif _, ok := fields[key]; !ok {\n");
+    out.push_str("\t\t\tfields[key] = value\n");
+    out.push_str("\t\t}\n");
+    out.push_str("\t}\n");
+    out.push_str("\treturn json.Marshal(fields)\n");
+    out.push_str("}\n\n");
+
+    let keys: Vec<String> = keys.iter().map(|key| go_string_literal(key)).collect();
+    out.push_str(&format!(
+        "func ({} *{}) UnmarshalJSON(data []byte) error {{\n",
+        receiver, type_name
+    ));
+    out.push_str(&format!("\ttype alias {}\n", type_name));
+    out.push_str("\tvar decoded alias\n");
+    out.push_str("\tif err := json.Unmarshal(data, &decoded); err != nil {\n");
+    out.push_str("\t\treturn err\n");
+    out.push_str("\t}\n");
+    out.push_str("\tvar fields map[string]json.RawMessage\n");
+    out.push_str("\tif err := json.Unmarshal(data, &fields); err != nil {\n");
+    out.push_str("\t\treturn err\n");
+    out.push_str("\t}\n");
+    if !keys.is_empty() {
+        out.push_str(&format!("\tfor _, key := range []string{{{}}} {{\n", keys.join(", ")));
+        out.push_str("\t\tdelete(fields, key)\n");
+        out.push_str("\t}\n");
+    }
+    out.push_str(&format!("\tdecoded.{} = nil\n", ident));
+    out.push_str("\tif len(fields) > 0 {\n");
+    out.push_str(&format!("\t\tdecoded.{} = fields\n", ident));
+    out.push_str("\t}\n");
+    out.push_str(&format!("\t*{} = {}(decoded)\n", receiver, type_name));
+    out.push_str("\treturn nil\n");
+    out.push_str("}\n\n");
+    out
+}
+
+fn go_string_literal(value: &str) -> String {
+    let mut out = String::from("\"");
+    for ch in value.chars() {
+        match ch {
+            '"' => out.push_str("\\\""),
+            '\\' => out.push_str("\\\\"),
+            '\n' => out.push_str("\\n"),
+            '\r' => out.push_str("\\r"),
+            '\t' => out.push_str("\\t"),
+            _ => out.push(ch),
+        }
+    }
+    out.push('"');
+    out
+}
+
+fn go_tag_name(key: &str, strategy: TagNamingStrategy) -> String {
+    match strategy {
+        TagNamingStrategy::AsIs => key.to_string(),
+        TagNamingStrategy::SnakeCase => snake_case(&case_words_from_key(key)),
+        TagNamingStrategy::CamelCase => lower_camel(&case_words_from_key(key)),
+        TagNamingStrategy::PascalCase => pascal_case(&case_words_from_key(key)),
+    }
+}
+
+fn go_type_for_field(
+    field: &Field,
+    parent_path: &[String],
+    registry: &NameRegistry,
+    pointer: bool,
+    options: &GoOptions,
+    imports: &mut GoImports,
+) -> String {
+    let path = field_path(parent_path, &field.key);
+    let base = match &field.field_type {
+        FieldType::Primitive(PrimitiveType::String) => {
+            match go_format_type(field, options) {
+                Some(go_type) => {
+                    imports.insert_type(go_type);
+                    go_type.name.clone()
+                }
+                None => "string".to_string(),
+            }
+        }
+        FieldType::Primitive(PrimitiveType::Int) if !options.json_number => {
+            go_int_type(field.format.as_deref()).to_string()
+        }
+        field_type => go_type(field_type, &path, registry, options, imports),
+    };
+
+    if pointer {
+        format!("*{}", base)
+    } else {
+        base
+    }
+}
+
+fn go_unknown_is_nilable(field: &Field, options: &GoOptions) -> bool {
+    let unknown = match &field.field_type {
+        FieldType::JsonValue => true,
+        FieldType::Union(_) => !options.emit_unions,
+        FieldType::Tuple(_) => !options.emit_tuples,
+        _ => false,
+    };
+    let name = options.unknown_type.name.as_str();
+    unknown
+        && (matches!(name, "any" | "interface{}")
+            || name.starts_with("[]")
+            || name.starts_with("map["))
+}
+
+fn go_format_type<'a>(field: &Field, options: &'a GoOptions) -> Option<&'a GoType> {
+    match &field.field_type {
+        FieldType::Primitive(PrimitiveType::String) => field
+            .format
+            .as_ref()
+            .and_then(|format| options.format_types.get(format)),
+        _ => None,
     }
+}
 
-    for def in defs {
-        out.push_str(&format!("type {} struct {{\n", def.name));
-        let mut used = HashMap::new();
-        for field in &def.node.fields {
-            let ident = field_identifier(DtoLanguage::Go, &field.key, &mut used);
-            let optional = match &field.field_type {
-                FieldType::Object(child) => !node_has_required(child),
-                _ => field.optional,
-            };
-            let field_type = go_type_for_field(field, &def.path, &registry, optional);
-            let tag = if optional {
-                format!("`json:\"{},omitempty\"`", field.key)
-            } else {
-                format!("`json:\"{}\"`", field.key)
-            };
-            out.push_str(&format!("    {} {} {}\n", ident, field_type, tag));
-        }
-        out.push_str("}\n\n");
+fn go_int_type(format: Option<&str>) -> &'static str {
+    match format {
+        Some("int8") => "int8",
+        Some("int16") => "int16",
+        Some("int32") => "int32",
+        Some("uint") => "uint",
+        Some("uint8") => "uint8",
+        Some("uint16") => "uint16",
+        Some("uint32") => "uint32",
+        Some("uint64") => "uint64",
+        _ => "int64",
     }
+}
 
-    Ok(out.trim_end().to_string())
+fn go_version_at_least(version: &str, minor: u32) -> Option<bool> {
+    let version = version.strip_prefix("go").unwrap_or(version);
+    let mut parts = version.split('.');
+    let major: u32 = parts.next()?.parse().ok()?;
+    let actual: u32 = parts.next().unwrap_or("0").parse().ok()?;
+    for patch in parts {
+        patch.parse::<u32>().ok()?;
+    }
+    Some(major > 1 || (major == 1 && actual >= minor))
 }
 
-fn go_type_for_field(
+fn go_field_is_pointer(
     field: &Field,
-    parent_path: &[String],
-    registry: &NameRegistry,
     optional: bool,
+    nullable: bool,
+    strategy: OptionalStrategy,
+) -> bool {
+    if matches!(field.field_type, FieldType::Array(_) | FieldType::Map(_)) {
+        return false;
+    }
+    match strategy {
+        OptionalStrategy::PointerWithOmitempty => optional,
+        OptionalStrategy::ValueWithOmitempty => false,
+        OptionalStrategy::AlwaysPointer => true,
+        OptionalStrategy::PointerForNullable => nullable,
+    }
+}
+
+fn go_type(
+    field_type: &FieldType,
+    path: &[String],
+    registry: &NameRegistry,
+    options: &GoOptions,
+    imports: &mut GoImports,
 ) -> String {
-    let base = match &field.field_type {
+    match field_type {
+        FieldType::Primitive(PrimitiveType::Int | PrimitiveType::Float) if options.json_number => {
+            imports.insert("encoding/json");
+            "json.Number".to_string()
+        }
         FieldType::Primitive(PrimitiveType::String) => "string".to_string(),
         FieldType::Primitive(PrimitiveType::Int) => "int64".to_string(),
         FieldType::Primitive(PrimitiveType::Float) => "float64".to_string(),
         FieldType::Primitive(PrimitiveType::Bool) => "bool".to_string(),
-        FieldType::JsonValue => "json.RawMessage".to_string(),
-        FieldType::Object(_) => {
-            let mut path = parent_path.to_vec();
-            path.push(field.key.clone());
-            registry
-                .get(&path)
-                .cloned()
-                .unwrap_or_else(|| "Record".to_string())
+        FieldType::JsonValue => {
+            imports.insert_type(&options.unknown_type);
+            options.unknown_type.name.clone()
+        }
+        FieldType::Array(item) => {
+            format!("[]{}", go_type(item, &item_path(path), registry, options, imports))
+        }
+        FieldType::Map(value) => format!(
+            "map[string]{}",
+            go_type(value, &map_value_path(path), registry, options, imports)
+        ),
+        FieldType::Object(_) => object_type_name(path, registry),
+        FieldType::Ref(name) => object_type_name(&[name.clone()], registry),
+        FieldType::Union(union) if options.emit_unions => {
+            object_type_name(&union_path(path, union), registry)
+        }
+        FieldType::Tuple(tuple) if options.emit_tuples => {
+            object_type_name(&tuple_path(path, tuple), registry)
+        }
+        FieldType::Union(_) | FieldType::Tuple(_) => {
+            imports.insert_type(&options.unknown_type);
+            options.unknown_type.name.clone()
         }
-    };
-
-    if optional {
-        format!("*{}", base)
-    } else {
-        base
     }
 }
 
-fn render_java(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
-    let mut registry = NameRegistry::new(name);
-    let mut defs = Vec::new();
-    collect_types(schema, Vec::new(), &mut registry, &mut defs);
+fn render_java(schema: &SchemaNode, root: TypeRoot, merge: bool) -> Result<String, DtoError> {
+    let (registry, defs) = collect_schema_types(schema, root, merge);
 
     let uses_json = node_uses_json(schema);
-    let uses_optional = schema_has_optional(schema);
-    let uses_rename = schema_has_rename(schema, DtoLanguage::Java);
+    let uses_optional = defs_have_optional(&defs);
+    let uses_rename = defs_have_rename(&defs, DtoLanguage::Java);
+    let uses_array = node_uses_array(schema);
+    let uses_map = node_uses_map(schema);
 
     let mut out = String::new();
     if uses_rename {
@@ -1041,10 +3662,16 @@ fn render_java(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
     if uses_json {
         out.push_str("import com.fasterxml.jackson.databind.JsonNode;\n");
     }
+    if uses_array {
+        out.push_str("import java.util.List;\n");
+    }
+    if uses_map {
+        out.push_str("import java.util.Map;\n");
+    }
     if uses_optional {
         out.push_str("import java.util.Optional;\n");
     }
-    if uses_rename || uses_json || uses_optional {
+    if uses_rename || uses_json || uses_optional || uses_array || uses_map {
         out.push('\n');
     }
 
@@ -1057,10 +3684,13 @@ fn render_java(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
             let rename = ident != field.key;
             let optional = match &field.field_type {
                 FieldType::Object(child) => !node_has_required(child),
-                _ => field.optional,
+                _ => field.optional || field.nullable,
             };
             let field_type = java_type_for_field(field, &def.path, &registry, optional);
 
+            if field.deprecated {
+                out.push_str("    @Deprecated\n");
+            }
             if rename {
                 out.push_str(&format!("    @JsonProperty(\"{}\")\n", field.key));
             }
@@ -1078,21 +3708,7 @@ fn java_type_for_field(
     registry: &NameRegistry,
     optional: bool,
 ) -> String {
-    let base = match &field.field_type {
-        FieldType::Primitive(PrimitiveType::String) => "String".to_string(),
-        FieldType::Primitive(PrimitiveType::Int) => "Long".to_string(),
-        FieldType::Primitive(PrimitiveType::Float) => "Double".to_string(),
-        FieldType::Primitive(PrimitiveType::Bool) => "Boolean".to_string(),
-        FieldType::JsonValue => "JsonNode".to_string(),
-        FieldType::Object(_) => {
-            let mut path = parent_path.to_vec();
-            path.push(field.key.clone());
-            registry
-                .get(&path)
-                .cloned()
-                .unwrap_or_else(|| "Record".to_string())
-        }
-    };
+    let base = java_type(&field.field_type, &field_path(parent_path, &field.key), registry);
 
     if optional {
         format!("Optional<{}>", base)
@@ -1101,13 +3717,30 @@ fn java_type_for_field(
     }
 }
 
-fn render_kotlin(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
-    let mut registry = NameRegistry::new(name);
-    let mut defs = Vec::new();
-    collect_types(schema, Vec::new(), &mut registry, &mut defs);
+fn java_type(field_type: &FieldType, path: &[String], registry: &NameRegistry) -> String {
+    match field_type {
+        FieldType::Primitive(PrimitiveType::String) => "String".to_string(),
+        FieldType::Primitive(PrimitiveType::Int) => "Long".to_string(),
+        FieldType::Primitive(PrimitiveType::Float) => "Double".to_string(),
+        FieldType::Primitive(PrimitiveType::Bool) => "Boolean".to_string(),
+        FieldType::JsonValue | FieldType::Union(_) | FieldType::Tuple(_) => "JsonNode".to_string(),
+        FieldType::Array(item) => {
+            format!("List<{}>", java_type(item, &item_path(path), registry))
+        }
+        FieldType::Map(value) => format!(
+            "Map<String, {}>",
+            java_type(value, &map_value_path(path), registry)
+        ),
+        FieldType::Object(_) => object_type_name(path, registry),
+        FieldType::Ref(name) => object_type_name(&[name.clone()], registry),
+    }
+}
+
+fn render_kotlin(schema: &SchemaNode, root: TypeRoot, merge: bool) -> Result<String, DtoError> {
+    let (registry, defs) = collect_schema_types(schema, root, merge);
 
     let uses_json = node_uses_json(schema);
-    let uses_rename = schema_has_rename(schema, DtoLanguage::Kotlin);
+    let uses_rename = defs_have_rename(&defs, DtoLanguage::Kotlin);
 
     let mut out = String::new();
     if uses_rename {
@@ -1128,10 +3761,13 @@ fn render_kotlin(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
             let rename = ident != field.key;
             let optional = match &field.field_type {
                 FieldType::Object(child) => !node_has_required(child),
-                _ => field.optional,
+                _ => field.optional || field.nullable,
             };
             let field_type = kotlin_type_for_field(field, &def.path, &registry, optional);
 
+            if let Some(message) = deprecation_message(field) {
+                out.push_str(&format!("    @Deprecated({})\n", kotlin_string_literal(message)));
+            }
             if rename {
                 out.push_str(&format!("    @JsonProperty(\"{}\")\n", field.key));
             }
@@ -1148,27 +3784,30 @@ fn render_kotlin(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
     Ok(out.trim_end().to_string())
 }
 
+fn kotlin_string_literal(value: &str) -> String {
+    let mut out = String::from("\"");
+    for ch in value.chars() {
+        match ch {
+            '"' => out.push_str("\\\""),
+            '\\' => out.push_str("\\\\"),
+            '$' => out.push_str("\\$"),
+            '\n' => out.push_str("\\n"),
+            '\r' => out.push_str("\\r"),
+            '\t' => out.push_str("\\t"),
+            _ => out.push(ch),
+        }
+    }
+    out.push('"');
+    out
+}
+
 fn kotlin_type_for_field(
     field: &Field,
     parent_path: &[String],
     registry: &NameRegistry,
     optional: bool,
 ) -> String {
-    let base = match &field.field_type {
-        FieldType::Primitive(PrimitiveType::String) => "String".to_string(),
-        FieldType::Primitive(PrimitiveType::Int) => "Long".to_string(),
-        FieldType::Primitive(PrimitiveType::Float) => "Double".to_string(),
-        FieldType::Primitive(PrimitiveType::Bool) => "Boolean".to_string(),
-        FieldType::JsonValue => "JsonNode".to_string(),
-        FieldType::Object(_) => {
-            let mut path = parent_path.to_vec();
-            path.push(field.key.clone());
-            registry
-                .get(&path)
-                .cloned()
-                .unwrap_or_else(|| "Record".to_string())
-        }
-    };
+    let base = kotlin_type(&field.field_type, &field_path(parent_path, &field.key), registry);
 
     if optional {
         format!("{}?", base)
@@ -1177,16 +3816,43 @@ fn kotlin_type_for_field(
     }
 }
 
-fn render_swift(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
-    let mut registry = NameRegistry::new(name);
-    let mut defs = Vec::new();
-    collect_types(schema, Vec::new(), &mut registry, &mut defs);
+fn kotlin_type(field_type: &FieldType, path: &[String], registry: &NameRegistry) -> String {
+    match field_type {
+        FieldType::Primitive(PrimitiveType::String) => "String".to_string(),
+        FieldType::Primitive(PrimitiveType::Int) => "Long".to_string(),
+        FieldType::Primitive(PrimitiveType::Float) => "Double".to_string(),
+        FieldType::Primitive(PrimitiveType::Bool) => "Boolean".to_string(),
+        FieldType::JsonValue | FieldType::Union(_) | FieldType::Tuple(_) => "JsonNode".to_string(),
+        FieldType::Array(item) => {
+            format!("List<{}>", kotlin_type(item, &item_path(path), registry))
+        }
+        FieldType::Map(value) => format!(
+            "Map<String, {}>",
+            kotlin_type(value, &map_value_path(path), registry)
+        ),
+        FieldType::Object(_) => object_type_name(path, registry),
+        FieldType::Ref(name) => object_type_name(&[name.clone()], registry),
+    }
+}
+
+fn render_swift(schema: &SchemaNode, root: TypeRoot, merge: bool) -> Result<String, DtoError> {
+    let (registry, defs) = collect_schema_types(schema, root, merge);
+    let recursive = recursive_fields(&defs);
 
     let uses_json = node_uses_json(schema);
 
     let mut out = String::new();
     for def in defs {
-        out.push_str(&format!("struct {}: Codable {{\n", def.name));
+        let is_recursive = def
+            .node
+            .fields
+            .iter()
+            .any(|field| recursive.contains(&field_path(&def.path, &field.key)));
+        if is_recursive {
+            out.push_str(&format!("final class {}: Codable {{\n", def.name));
+        } else {
+            out.push_str(&format!("struct {}: Codable {{\n", def.name));
+        }
         let mut used = HashMap::new();
         let mut coding_keys = Vec::new();
         for field in &def.node.fields {
@@ -1194,10 +3860,16 @@ fn render_swift(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
             let rename = ident != field.key;
             let optional = match &field.field_type {
                 FieldType::Object(child) => !node_has_required(child),
-                _ => field.optional,
+                _ => field.optional || field.nullable,
             };
             let field_type = swift_type_for_field(field, &def.path, &registry, optional);
 
+            if let Some(message) = deprecation_message(field) {
+                out.push_str(&format!(
+                    "    @available(*, deprecated, message: {})\n",
+                    swift_string_literal(message)
+                ));
+            }
             out.push_str(&format!("    let {}: {}\n", ident, field_type));
             if rename {
                 coding_keys.push(format!("        case {} = \"{}\"", ident, field.key));
@@ -1223,67 +3895,610 @@ fn render_swift(schema: &SchemaNode, name: &str) -> Result<String, DtoError> {
     Ok(out.trim_end().to_string())
 }
 
+fn swift_string_literal(value: &str) -> String {
+    let mut out = String::from("\"");
+    for ch in value.chars() {
+        match ch {
+            '"' => out.push_str("\\\""),
+            '\\' => out.push_str("\\\\"),
+            '\n' => out.push_str("\\n"),
+            '\r' => out.push_str("\\r"),
+            '\t' => out.push_str("\\t"),
+            _ => out.push(ch),
+        }
+    }
+    out.push('"');
+    out
+}
+
 fn swift_type_for_field(
     field: &Field,
     parent_path: &[String],
     registry: &NameRegistry,
     optional: bool,
 ) -> String {
-    let base = match &field.field_type {
+    let base = swift_type(&field.field_type, &field_path(parent_path, &field.key), registry);
+
+    if optional {
+        format!("{}?", base)
+    } else {
+        base
+    }
+}
+
+fn swift_type(field_type: &FieldType, path: &[String], registry: &NameRegistry) -> String {
+    match field_type {
         FieldType::Primitive(PrimitiveType::String) => "String".to_string(),
         FieldType::Primitive(PrimitiveType::Int) => "Int".to_string(),
         FieldType::Primitive(PrimitiveType::Float) => "Double".to_string(),
         FieldType::Primitive(PrimitiveType::Bool) => "Bool".to_string(),
-        FieldType::JsonValue => "JSONValue".to_string(),
-        FieldType::Object(_) => {
-            let mut path = parent_path.to_vec();
-            path.push(field.key.clone());
-            registry
-                .get(&path)
-                .cloned()
-                .unwrap_or_else(|| "Record".to_string())
-        }
+        FieldType::JsonValue | FieldType::Union(_) | FieldType::Tuple(_) => "JSONValue".to_string(),
+        FieldType::Array(item) => format!("[{}]", swift_type(item, &item_path(path), registry)),
+        FieldType::Map(value) => format!(
+            "[String: {}]",
+            swift_type(value, &map_value_path(path), registry)
+        ),
+        FieldType::Object(_) => object_type_name(path, registry),
+        FieldType::Ref(name) => object_type_name(&[name.clone()], registry),
+    }
+}
+
+fn render_protobuf(schema: &SchemaNode, root: TypeRoot, merge: bool) -> Result<String, DtoError> {
+    let (registry, defs) = collect_schema_types(schema, root, merge);
+    let top_level = match root {
+        TypeRoot::Named(_) => 0,
+        TypeRoot::Components => 1,
     };
 
-    if optional {
-        format!("{}?", base)
-    } else {
-        base
+    let mut body = String::new();
+    for def in defs.iter().filter(|def| def.path.len() == top_level) {
+        render_protobuf_message(&def.name, def.node, 0, &registry, &mut body);
+        body.push('\n');
+    }
+
+    let mut out = String::from("syntax = \"proto3\";\n\n");
+    if node_uses_json(schema) || protobuf_uses_nested_collections(schema) {
+        out.push_str("import \"google/protobuf/struct.proto\";\n\n");
     }
+    out.push_str(&body);
+    Ok(out.trim_end().to_string())
 }
 
-fn schema_has_optional(node: &SchemaNode) -> bool {
-    for field in &node.fields {
-        match &field.field_type {
-            FieldType::Object(child) => {
-                if !node_has_required(child) || schema_has_optional(child) {
-                    return true;
+fn render_protobuf_message(
+    name: &str,
+    node: &SchemaNode,
+    depth: usize,
+    registry: &NameRegistry,
+    out: &mut String,
+) {
+    let indent = "  ".repeat(depth);
+    out.push_str(&format!("{}message {} {{\n", indent, name));
+
+    let mut used = HashMap::new();
+    let mut nested_names = HashSet::new();
+    let mut nested = Vec::new();
+    let mut fields = String::new();
+    for (index, field) in node.fields.iter().enumerate() {
+        let ident = field_identifier(DtoLanguage::Protobuf, &field.key, &mut used);
+        let type_name = protobuf_type(
+            &field.field_type,
+            &pascal_case(&words_from_key(&field.key)),
+            registry,
+            &mut nested_names,
+            &mut nested,
+        );
+        let optional = match &field.field_type {
+            FieldType::Primitive(_) => field.optional || field.nullable,
+            _ => false,
+        };
+        let label = if optional { "optional " } else { "" };
+        let mut field_options = Vec::new();
+        if protobuf_json_name(&ident) != field.key {
+            field_options.push(format!("json_name = \"{}\"", field.key));
+        }
+        if field.deprecated {
+            field_options.push("deprecated = true".to_string());
+        }
+        let json_name = if field_options.is_empty() {
+            String::new()
+        } else {
+            format!(" [{}]", field_options.join(", "))
+        };
+        fields.push_str(&format!(
+            "{}  {}{} {} = {}{};\n",
+            indent,
+            label,
+            type_name,
+            ident,
+            index + 1,
+            json_name
+        ));
+    }
+
+    for (nested_name, child) in nested {
+        render_protobuf_message(&nested_name, child, depth + 1, registry, out);
+        out.push('\n');
+    }
+    out.push_str(&fields);
+    out.push_str(&format!("{}}}\n", indent));
+}
+
+fn protobuf_type<'a>(
+    field_type: &'a FieldType,
+    name: &str,
+    registry: &NameRegistry,
+    nested_names: &mut HashSet<String>,
+    nested: &mut Vec<(String, &'a SchemaNode)>,
+) -> String {
+    match field_type {
+        FieldType::Primitive(PrimitiveType::String) => "string".to_string(),
+        FieldType::Primitive(PrimitiveType::Int) => "int64".to_string(),
+        FieldType::Primitive(PrimitiveType::Float) => "double".to_string(),
+        FieldType::Primitive(PrimitiveType::Bool) => "bool".to_string(),
+        FieldType::JsonValue | FieldType::Union(_) | FieldType::Tuple(_) => {
+            "google.protobuf.Value".to_string()
+        }
+        FieldType::Array(item) => match item.as_ref() {
+            FieldType::Array(_) | FieldType::Map(_) => "repeated google.protobuf.Value".to_string(),
+            item => format!(
+                "repeated {}",
+                protobuf_type(item, &singular_type_name(name), registry, nested_names, nested)
+            ),
+        },
+        FieldType::Map(value) => match value.as_ref() {
+            FieldType::Array(_) | FieldType::Map(_) => {
+                "map<string, google.protobuf.Value>".to_string()
+            }
+            value => format!(
+                "map<string, {}>",
+                protobuf_type(value, &format!("{}Value", name), registry, nested_names, nested)
+            ),
+        },
+        FieldType::Object(child) => {
+            let mut unique = name.to_string();
+            let mut suffix = 2;
+            while !nested_names.insert(unique.clone()) {
+                unique = format!("{}{}", name, suffix);
+                suffix += 1;
+            }
+            nested.push((unique.clone(), child));
+            unique
+        }
+        FieldType::Ref(name) => object_type_name(&[name.clone()], registry),
+    }
+}
+
+const JSON_SCHEMA_DIALECT: &str = "https://json-schema.org/draft/2020-12/schema";
+
+enum JsonSchemaValue {
+    Literal(String),
+    List(Vec<JsonSchemaValue>),
+    Object(JsonSchemaEntries),
+}
+
+type JsonSchemaEntries = Vec<(String, JsonSchemaValue)>;
+
+impl JsonSchemaValue {
+    fn string(value: &str) -> Self {
+        JsonSchemaValue::Literal(json_literal(&JsonValue::String(value.to_string())))
+    }
+
+    fn from_json(value: &JsonValue) -> Self {
+        match value {
+            JsonValue::Array(items) => {
+                JsonSchemaValue::List(items.iter().map(JsonSchemaValue::from_json).collect())
+            }
+            JsonValue::Object(entries) => JsonSchemaValue::Object(
+                entries
+                    .iter()
+                    .map(|(key, value)| (key.clone(), JsonSchemaValue::from_json(value)))
+                    .collect(),
+            ),
+            _ => JsonSchemaValue::Literal(json_literal(value)),
+        }
+    }
+
+    fn write(&self, depth: usize, out: &mut String) {
+        let indent = "  ".repeat(depth + 1);
+        match self {
+            JsonSchemaValue::Literal(value) => out.push_str(value),
+            JsonSchemaValue::List(items) if items.is_empty() => out.push_str("[]"),
+            JsonSchemaValue::Object(entries) if entries.is_empty() => out.push_str("{}"),
+            JsonSchemaValue::List(items) => {
+                out.push('[');
+                for (index, item) in items.iter().enumerate() {
+                    out.push_str(if index == 0 { "\n" } else { ",\n" });
+                    out.push_str(&indent);
+                    item.write(depth + 1, out);
                 }
+                out.push_str(&format!("\n{}]", "  ".repeat(depth)));
             }
-            _ => {
-                if field.optional {
-                    return true;
+            JsonSchemaValue::Object(entries) => {
+                out.push('{');
+                for (index, (key, value)) in entries.iter().enumerate() {
+                    out.push_str(if index == 0 { "\n" } else { ",\n" });
+                    out.push_str(&format!("{}{}: ", indent, JsonValue::from(key.as_str())));
+                    value.write(depth + 1, out);
                 }
+                out.push_str(&format!("\n{}}}", "  ".repeat(depth)));
             }
         }
     }
-    false
 }
 
-fn schema_has_rename(node: &SchemaNode, lang: DtoLanguage) -> bool {
-    let mut used = HashMap::new();
+fn json_literal(value: &JsonValue) -> String {
+    match value.as_f64() {
+        Some(number) if value.is_f64() && number.fract() == 0.0 && number.abs() < 1e15 => {
+            format!("{}", number as i64)
+        }
+        _ => value.to_string(),
+    }
+}
+
+fn render_json_schema(schema: &SchemaNode, root: TypeRoot) -> Result<String, DtoError> {
+    let mut defs = Vec::new();
+    let mut entries = vec![(
+        "$schema".to_string(),
+        JsonSchemaValue::string(JSON_SCHEMA_DIALECT),
+    )];
+    match root {
+        TypeRoot::Named(name) => {
+            entries.push(("title".to_string(), JsonSchemaValue::string(name)));
+            entries.extend(json_schema_object(schema, &mut defs));
+        }
+        TypeRoot::Components => {
+            let mut components = Vec::new();
+            for field in &schema.fields {
+                if let Some(node) = nested_node(&field.field_type) {
+                    let def = JsonSchemaValue::Object(json_schema_object(node, &mut defs));
+                    components.push((field.key.clone(), def));
+                }
+            }
+            components.append(&mut defs);
+            defs = components;
+        }
+    }
+    if !defs.is_empty() {
+        entries.push(("$defs".to_string(), JsonSchemaValue::Object(defs)));
+    }
+    let mut out = String::new();
+    JsonSchemaValue::Object(entries).write(0, &mut out);
+    Ok(out)
+}
+
+fn json_schema_object(
+    node: &SchemaNode,
+    defs: &mut JsonSchemaEntries,
+) -> JsonSchemaEntries {
+    let mut entries = Vec::new();
+    if let Some(doc) = &node.doc {
+        entries.push(("description".to_string(), JsonSchemaValue::string(doc)));
+    }
+    entries.push(("type".to_string(), JsonSchemaValue::string("object")));
+    if !node.embeds.is_empty() {
+        let embeds = node
+            .embeds
+            .iter()
+            .map(|name| JsonSchemaValue::Object(json_schema_ref(name)))
+            .collect();
+        entries.push(("allOf".to_string(), JsonSchemaValue::List(embeds)));
+    }
+    let mut properties = Vec::new();
+    let mut required = Vec::new();
     for field in &node.fields {
-        let ident = field_identifier(lang, &field.key, &mut used);
-        if ident != field.key {
-            return true;
+        let is_required = match &field.field_type {
+            FieldType::Object(child) => node_has_required(child),
+            _ => !field.optional,
+        };
+        if is_required {
+            required.push(JsonSchemaValue::string(&field.key));
+        }
+        properties.push((field.key.clone(), json_schema_field(field, defs)));
+    }
+    if !properties.is_empty() {
+        entries.push(("properties".to_string(), JsonSchemaValue::Object(properties)));
+    }
+    if !required.is_empty() {
+        entries.push(("required".to_string(), JsonSchemaValue::List(required)));
+    }
+    if node.additional_properties {
+        entries.push((
+            "additionalProperties".to_string(),
+            JsonSchemaValue::Literal("true".to_string()),
+        ));
+    }
+    entries
+}
+
+fn json_schema_field(
+    field: &Field,
+    defs: &mut JsonSchemaEntries,
+) -> JsonSchemaValue {
+    let mut entries = Vec::new();
+    if let Some(doc) = &field.doc {
+        entries.push(("description".to_string(), JsonSchemaValue::string(doc)));
+    }
+    let type_entries = json_schema_type(&field.field_type, defs);
+    if field.nullable {
+        entries.extend(json_schema_nullable(type_entries));
+    } else {
+        entries.extend(type_entries);
+    }
+    if let Some(format) = &field.format {
+        entries.push(("format".to_string(), JsonSchemaValue::string(format)));
+    }
+    if let Some(values) = &field.enum_values {
+        let literals = values
+            .iter()
+            .map(|value| match value.literal() {
+                EnumLiteral::Int(value) => JsonSchemaValue::Literal(value.to_string()),
+                EnumLiteral::String(value) => JsonSchemaValue::string(value),
+            })
+            .collect();
+        entries.push(("enum".to_string(), JsonSchemaValue::List(literals)));
+        let names: Option<Vec<JsonSchemaValue>> = values
+            .iter()
+            .map(|value| value.name().map(JsonSchemaValue::string))
+            .collect();
+        if let Some(names) = names {
+            entries.push(("x-enum-varnames".to_string(), JsonSchemaValue::List(names)));
+        }
+    }
+    let constraints = &field.constraints;
+    let (min_length, max_length) = match &field.field_type {
+        FieldType::Array(_) => ("minItems", "maxItems"),
+        FieldType::Map(_) => ("minProperties", "maxProperties"),
+        _ => ("minLength", "maxLength"),
+    };
+    let bounds = [
+        ("minimum", constraints.min.map(JsonValue::from)),
+        ("maximum", constraints.max.map(JsonValue::from)),
+        (min_length, constraints.min_length.map(JsonValue::from)),
+        (max_length, constraints.max_length.map(JsonValue::from)),
+        ("default", field.default.clone()),
+        ("deprecated", field.deprecated.then_some(JsonValue::Bool(true))),
+    ];
+    for (key, value) in bounds {
+        if let Some(value) = value {
+            entries.push((key.to_string(), JsonSchemaValue::from_json(&value)));
+        }
+    }
+    JsonSchemaValue::Object(entries)
+}
+
+fn json_schema_type(
+    field_type: &FieldType,
+    defs: &mut JsonSchemaEntries,
+) -> JsonSchemaEntries {
+    let type_entry = |name: &str| vec![("type".to_string(), JsonSchemaValue::string(name))];
+    match field_type {
+        FieldType::Primitive(PrimitiveType::String) => type_entry("string"),
+        FieldType::Primitive(PrimitiveType::Int) => type_entry("integer"),
+        FieldType::Primitive(PrimitiveType::Float) => type_entry("number"),
+        FieldType::Primitive(PrimitiveType::Bool) => type_entry("boolean"),
+        FieldType::JsonValue => Vec::new(),
+        FieldType::Object(child) => json_schema_object(child, defs),
+        FieldType::Array(item) => {
+            let mut entries = type_entry("array");
+            let item = JsonSchemaValue::Object(json_schema_type(item, defs));
+            entries.push(("items".to_string(), item));
+            entries
+        }
+        FieldType::Map(value) => {
+            let mut entries = type_entry("object");
+            let value = JsonSchemaValue::Object(json_schema_type(value, defs));
+            entries.push(("additionalProperties".to_string(), value));
+            entries
+        }
+        FieldType::Ref(name) => json_schema_ref(name),
+        FieldType::Union(union) => {
+            let variants = union
+                .variants
+                .iter()
+                .map(|variant| JsonSchemaValue::Object(json_schema_ref(&variant.type_name)))
+                .collect();
+            let mut entries = vec![("oneOf".to_string(), JsonSchemaValue::List(variants))];
+            if let Some(property) = &union.discriminator {
+                let mut discriminator = vec![(
+                    "propertyName".to_string(),
+                    JsonSchemaValue::string(property),
+                )];
+                let mapping: JsonSchemaEntries = union
+                    .variants
+                    .iter()
+                    .filter(|variant| variant.tag != variant.type_name)
+                    .map(|variant| {
+                        let target = format!("#/$defs/{}", variant.type_name);
+                        (variant.tag.clone(), JsonSchemaValue::string(&target))
+                    })
+                    .collect();
+                if !mapping.is_empty() {
+                    discriminator.push(("mapping".to_string(), JsonSchemaValue::Object(mapping)));
+                }
+                entries.push((
+                    "discriminator".to_string(),
+                    JsonSchemaValue::Object(discriminator),
+                ));
+            }
+            json_schema_named_def(union.name.as_deref(), entries, defs)
+        }
+        FieldType::Tuple(tuple) => {
+            let mut entries = type_entry("array");
+            let items = tuple
+                .items
+                .iter()
+                .map(|item| JsonSchemaValue::Object(json_schema_type(item, defs)))
+                .collect();
+            entries.push(("prefixItems".to_string(), JsonSchemaValue::List(items)));
+            entries.push(("items".to_string(), JsonSchemaValue::Literal("false".to_string())));
+            json_schema_named_def(tuple.name.as_deref(), entries, defs)
+        }
+    }
+}
+
+fn json_schema_named_def(
+    name: Option<&str>,
+    entries: JsonSchemaEntries,
+    defs: &mut JsonSchemaEntries,
+) -> JsonSchemaEntries {
+    let Some(name) = name else {
+        return entries;
+    };
+    if defs.iter().all(|(existing, _)| existing != name) {
+        defs.push((name.to_string(), JsonSchemaValue::Object(entries)));
+    }
+    json_schema_ref(name)
+}
+
+fn json_schema_ref(name: &str) -> JsonSchemaEntries {
+    let target = format!("#/$defs/{}", name);
+    vec![("$ref".to_string(), JsonSchemaValue::string(&target))]
+}
+
+fn json_schema_nullable(mut entries: JsonSchemaEntries) -> JsonSchemaEntries {
+    if entries.is_empty() {
+        return entries;
+    }
+    let null = JsonSchemaValue::string("null");
+    match entries.iter_mut().find(|(key, _)| key == "type") {
+        Some((_, value)) => {
+            let name = std::mem::replace(value, JsonSchemaValue::List(Vec::new()));
+            *value = JsonSchemaValue::List(vec![name, null]);
+            entries
+        }
+        None => {
+            let null = vec![("type".to_string(), null)];
+            let any_of = vec![JsonSchemaValue::Object(entries), JsonSchemaValue::Object(null)];
+            vec![("anyOf".to_string(), JsonSchemaValue::List(any_of))]
+        }
+    }
+}
+
+fn protobuf_json_name(ident: &str) -> String {
+    let mut out = String::new();
+    let mut upper = false;
+    for ch in ident.chars() {
+        if ch == '_' {
+            upper = true;
+        } else if upper {
+            out.push(ch.to_ascii_uppercase());
+            upper = false;
+        } else {
+            out.push(ch);
         }
-        if let FieldType::Object(child) = &field.field_type {
-            if schema_has_rename(child, lang) {
-                return true;
+    }
+    out
+}
+
+fn protobuf_uses_nested_collections(node: &SchemaNode) -> bool {
+    node_contains(node, |field_type| match field_type {
+        FieldType::Array(item) | FieldType::Map(item) => {
+            matches!(item.as_ref(), FieldType::Array(_) | FieldType::Map(_))
+        }
+        _ => false,
+    })
+}
+
+fn defs_have_optional(defs: &[TypeDef]) -> bool {
+    defs.iter().any(|def| {
+        def.node.fields.iter().any(|field| match &field.field_type {
+            FieldType::Object(child) => !node_has_required(child),
+            _ => field.optional || field.nullable,
+        })
+    })
+}
+
+fn recursive_fields(defs: &[TypeDef]) -> HashSet<Vec<String>> {
+    let mut edges = HashMap::new();
+    for def in defs {
+        let targets: Vec<Vec<String>> = def
+            .node
+            .fields
+            .iter()
+            .filter_map(|field| value_target(&def.path, field))
+            .collect();
+        edges.insert(def.path.clone(), targets);
+    }
+
+    let mut recursive = HashSet::new();
+    for def in defs {
+        for field in &def.node.fields {
+            if let Some(target) = value_target(&def.path, field) {
+                if path_reaches(&edges, target, &def.path) {
+                    recursive.insert(field_path(&def.path, &field.key));
+                }
             }
         }
     }
+    recursive
+}
+
+fn value_target(parent_path: &[String], field: &Field) -> Option<Vec<String>> {
+    match &field.field_type {
+        FieldType::Object(_) => Some(field_path(parent_path, &field.key)),
+        FieldType::Ref(name) => Some(vec![name.clone()]),
+        _ => None,
+    }
+}
+
+fn path_reaches(
+    edges: &HashMap<Vec<String>, Vec<Vec<String>>>,
+    from: Vec<String>,
+    to: &[String],
+) -> bool {
+    let mut stack = vec![from];
+    let mut seen = HashSet::new();
+    while let Some(path) = stack.pop() {
+        if path == to {
+            return true;
+        }
+        if !seen.insert(path.clone()) {
+            continue;
+        }
+        if let Some(targets) = edges.get(&path) {
+            stack.extend(targets.iter().cloned());
+        }
+    }
     false
 }
 
+fn defs_have_forward_refs(defs: &[TypeDef]) -> bool {
+    let positions: HashMap<&[String], usize> = defs
+        .iter()
+        .enumerate()
+        .map(|(index, def)| (def.path.as_slice(), index))
+        .collect();
+    defs.iter().enumerate().any(|(index, def)| {
+        def.node.fields.iter().any(|field| match referenced_type(&field.field_type) {
+            Some(name) => positions
+                .get([name.clone()].as_slice())
+                .map(|position| *position >= index)
+                .unwrap_or(false),
+            None => false,
+        })
+    })
+}
+
+fn referenced_type(field_type: &FieldType) -> Option<&String> {
+    match field_type {
+        FieldType::Ref(name) => Some(name),
+        FieldType::Array(item) | FieldType::Map(item) => referenced_type(item),
+        _ => None,
+    }
+}
+
+fn defs_have_deprecated(defs: &[TypeDef]) -> bool {
+    defs.iter().any(|def| def.node.fields.iter().any(|field| field.deprecated))
+}
+
+fn defs_have_rename(defs: &[TypeDef], lang: DtoLanguage) -> bool {
+    defs.iter().any(|def| {
+        let mut used = HashMap::new();
+        def.node
+            .fields
+            .iter()
+            .any(|field| field_identifier(lang, &field.key, &mut used) != field.key)
+    })
+}
+
 const SWIFT_JSON_VALUE: &str = "enum JSONValue: Codable {\n    case string(String)\n    case number(Double)\n    case bool(Bool)\n    case object([String: JSONValue])\n    case array([JSONValue])\n    case null\n\n    init(from decoder: Decoder) throws {\n        let container = try decoder.singleValueContainer()\n        if container.decodeNil() {\n            self = .null\n        } else if let value = try? container.decode(Bool.self) {\n            self = .bool(value)\n        } else if let value = try? container.decode(Double.self) {\n            self = .number(value)\n        } else if let value = try? container.decode(String.self) {\n            self = .string(value)\n        } else if let value = try? container.decode([String: JSONValue].self) {\n            self = .object(value)\n        } else if let value = try? container.decode([JSONValue].self) {\n            self = .array(value)\n        } else {\n            throw DecodingError.typeMismatch(JSONValue.self, DecodingError.Context(codingPath: decoder.codingPath, debugDescription: \"Unsupported JSON value\"))\n        }\n    }\n\n    func encode(to encoder: Encoder) throws {\n        var container = encoder.singleValueContainer()\n        switch self {\n        case .string(let value):\n            try container.encode(value)\n        case .number(let value):\n            try container.encode(value)\n        case .bool(let value):\n            try container.encode(value)\n        case .object(let value):\n            try container.encode(value)\n        case .array(let value):\n            try container.encode(value)\n        case .null:\n            try container.encodeNil()\n        }\n    }\n}\n";
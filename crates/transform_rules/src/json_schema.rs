@@ -0,0 +1,190 @@
+use std::collections::{HashMap, HashSet, VecDeque};
+use std::fs;
+use std::path::{Path, PathBuf};
+
+use serde_yaml::{Mapping as YamlMapping, Value as YamlValue};
+
+use crate::dto::{DtoError, SchemaNode};
+use crate::openapi::{is_object_schema, lower_components, SCHEMA_REF_PREFIX};
+
+pub(crate) fn build_json_schema(path: &Path, name: Option<&str>) -> Result<SchemaNode, DtoError> {
+    let components = resolve_components(&mut RefResolver::default(), path, name)?;
+    let components: Vec<(String, &YamlValue)> = components
+        .iter()
+        .map(|(name, schema)| (name.clone(), schema))
+        .collect();
+    lower_components(&components)
+}
+
+pub fn json_schema_sources(path: &Path) -> Vec<PathBuf> {
+    let mut resolver = RefResolver::default();
+    let _ = resolve_components(&mut resolver, path, None);
+    let mut sources: Vec<PathBuf> = resolver.documents.into_keys().collect();
+    sources.sort();
+    sources
+}
+
+fn resolve_components(
+    resolver: &mut RefResolver,
+    path: &Path,
+    name: Option<&str>,
+) -> Result<Vec<(String, YamlValue)>, DtoError> {
+    let root_path = resolver.load(path)?;
+    let root = resolver
+        .lookup(&root_path, "")
+        .filter(|schema| is_object_schema(schema))
+        .ok_or_else(|| DtoError::new("JSON Schema root must be an object schema"))?;
+    let root_name = match name {
+        Some(name) => name.to_string(),
+        None => root
+            .get("title")
+            .and_then(|title| title.as_str())
+            .unwrap_or("Record")
+            .to_string(),
+    };
+    resolver.register(root_path, String::new(), root_name);
+
+    let mut components = Vec::new();
+    while let Some((path, pointer, name)) = resolver.pending.pop_front() {
+        let schema = resolver
+            .lookup(&path, &pointer)
+            .cloned()
+            .unwrap_or(YamlValue::Null);
+        let schema = resolver
+            .rewrite(&schema, &path, false)
+            .map_err(|err| err.with_field(&name))?;
+        components.push((name, schema));
+    }
+    Ok(components)
+}
+
+#[derive(Default)]
+struct RefResolver {
+    documents: HashMap<PathBuf, YamlValue>,
+    names: HashMap<(PathBuf, String), String>,
+    used: HashSet<String>,
+    pending: VecDeque<(PathBuf, String, String)>,
+}
+
+impl RefResolver {
+    fn load(&mut self, path: &Path) -> Result<PathBuf, DtoError> {
+        let read_error = |err: std::io::Error| {
+            DtoError::new(format!("failed to read {}: {}", path.display(), err))
+        };
+        let path = fs::canonicalize(path).map_err(read_error)?;
+        if self.documents.contains_key(&path) {
+            return Ok(path);
+        }
+        let source = fs::read_to_string(&path).map_err(read_error)?;
+        let document: YamlValue = serde_yaml::from_str(&source).map_err(|err| {
+            DtoError::new(format!("invalid JSON Schema {}: {}", path.display(), err))
+        })?;
+        self.documents.insert(path.clone(), document);
+        Ok(path)
+    }
+
+    fn lookup(&self, path: &Path, pointer: &str) -> Option<&YamlValue> {
+        let mut value = self.documents.get(path)?;
+        if pointer.is_empty() {
+            return Some(value);
+        }
+        for segment in pointer.strip_prefix('/')?.split('/') {
+            let segment = segment.replace("~1", "/").replace("~0", "~");
+            value = match value {
+                YamlValue::Mapping(_) => value.get(segment.as_str())?,
+                YamlValue::Sequence(values) => values.get(segment.parse::<usize>().ok()?)?,
+                _ => return None,
+            };
+        }
+        Some(value)
+    }
+
+    fn register(&mut self, path: PathBuf, pointer: String, candidate: String) -> String {
+        let key = (path, pointer);
+        if let Some(name) = self.names.get(&key) {
+            return name.clone();
+        }
+        let mut name = candidate.clone();
+        let mut suffix = 2;
+        while !self.used.insert(name.clone()) {
+            name = format!("{}{}", candidate, suffix);
+            suffix += 1;
+        }
+        self.names.insert(key.clone(), name.clone());
+        self.pending.push_back((key.0, key.1, name.clone()));
+        name
+    }
+
+    fn resolve(&mut self, reference: &str, base: &Path) -> Result<String, DtoError> {
+        if reference.contains("://") {
+            return Err(DtoError::new(format!("unsupported $ref: {}", reference)));
+        }
+        let (file, pointer) = reference.split_once('#').unwrap_or((reference, ""));
+        let path = if file.is_empty() {
+            base.to_path_buf()
+        } else {
+            let dir = base.parent().unwrap_or_else(|| Path::new(""));
+            self.load(&dir.join(file))?
+        };
+        if let Some(name) = self.names.get(&(path.clone(), pointer.to_string())) {
+            return Ok(name.clone());
+        }
+
+        let schema = self
+            .lookup(&path, pointer)
+            .ok_or_else(|| DtoError::new(format!("unknown $ref: {}", reference)))?;
+        let candidate = match pointer.rsplit('/').next().filter(|segment| !segment.is_empty()) {
+            Some(segment) => segment.replace("~1", "/").replace("~0", "~"),
+            None => schema
+                .get("title")
+                .and_then(|title| title.as_str())
+                .map(|title| title.to_string())
+                .or_else(|| {
+                    path.file_stem()
+                        .and_then(|stem| stem.to_str())
+                        .map(|stem| stem.to_string())
+                })
+                .unwrap_or_else(|| "Record".to_string()),
+        };
+        Ok(self.register(path, pointer.to_string(), candidate))
+    }
+
+    fn rewrite(
+        &mut self,
+        value: &YamlValue,
+        base: &Path,
+        in_properties: bool,
+    ) -> Result<YamlValue, DtoError> {
+        match value {
+            YamlValue::Mapping(mapping) => {
+                let mut out = YamlMapping::new();
+                for (key, value) in mapping.iter() {
+                    let key_name = key.as_str().unwrap_or("");
+                    if !in_properties && matches!(key_name, "$defs" | "definitions") {
+                        continue;
+                    }
+                    if !in_properties && key_name == "$ref" {
+                        let reference = value
+                            .as_str()
+                            .ok_or_else(|| DtoError::new("$ref must be a string"))?;
+                        let name = self.resolve(reference, base)?;
+                        out.insert(
+                            key.clone(),
+                            YamlValue::String(format!("{}{}", SCHEMA_REF_PREFIX, name)),
+                        );
+                        continue;
+                    }
+                    let child_in_properties = !in_properties && key_name == "properties";
+                    out.insert(key.clone(), self.rewrite(value, base, child_in_properties)?);
+                }
+                Ok(YamlValue::Mapping(out))
+            }
+            YamlValue::Sequence(values) => values
+                .iter()
+                .map(|value| self.rewrite(value, base, false))
+                .collect::<Result<Vec<_>, _>>()
+                .map(YamlValue::Sequence),
+            other => Ok(other.clone()),
+        }
+    }
+}
@@ -1,21 +1,46 @@
+mod batch;
 mod cache;
+mod diff;
 mod error;
+mod json_schema;
 mod locator;
 mod model;
+mod openapi;
 mod path;
+mod schema_validator;
 mod dto;
+mod template;
 mod transform;
 mod validator;
 
 /// Library version from Cargo.toml
 pub const VERSION: &str = env!("CARGO_PKG_VERSION");
 
+pub use batch::{
+    generate_dto_batch, generate_dto_batch_with_jobs, BatchMode, DtoBatch, DtoBatchFailure,
+    DtoBatchOutput,
+};
+pub use diff::{diff_generated_file, unified_diff};
 pub use error::{
     ErrorCode, RuleError, TransformError, TransformErrorKind, TransformWarning, ValidationResult,
     YamlLocation,
 };
-pub use dto::{generate_dto, DtoError, DtoLanguage};
-pub use model::{Expr, ExprChain, ExprOp, ExprRef, InputFormat, InputSpec, Mapping, RuleFile};
+pub use dto::{
+    build_dto_schema, build_dto_schema_from_json_schema, build_dto_schema_from_openapi,
+    generate_dto, generate_dto_from_json_schema, generate_dto_from_json_schema_with_warnings,
+    generate_dto_from_openapi, generate_dto_from_openapi_with_warnings, generate_dto_from_schema,
+    generate_dto_from_schema_with_warnings, generate_dto_with_options, generate_dto_with_warnings,
+    AdditionalPropertiesPolicy, DtoError, DtoLanguage, DtoOptions, DtoRoot, DtoSchema, DtoWarning,
+    Field, FieldConstraints, FieldOrder, FieldType, GoHeader, GoIndent, GoOptions, GoType,
+    OptionalStrategy, PrimitiveType, SchemaNode, TagNamingStrategy, TupleType, UnionType,
+    UnionVariant,
+};
+pub use json_schema::json_schema_sources;
+pub use model::{
+    DtoField, DtoHint, EnumLiteral, EnumValue, Expr, ExprChain, ExprOp, ExprRef, InputFormat,
+    InputSpec, Mapping, RuleFile,
+};
+pub use schema_validator::validate_dto_schema;
 pub use transform::{
     preflight_validate, preflight_validate_with_warnings, transform, transform_stream,
     transform_with_warnings, TransformStream, TransformStreamItem,
@@ -1,4 +1,4 @@
-use serde::Deserialize;
+use serde::{Deserialize, Serialize};
 use serde_json::Value as JsonValue;
 
 #[derive(Debug, Deserialize, Clone)]
@@ -16,6 +16,7 @@ pub struct RuleFile {
 #[serde(deny_unknown_fields)]
 pub struct OutputSpec {
     pub name: Option<String>,
+    pub description: Option<String>,
 }
 
 #[derive(Debug, Deserialize, Clone)]
@@ -78,6 +79,70 @@ pub struct Mapping {
     #[serde(default)]
     pub required: bool,
     pub default: Option<JsonValue>,
+    pub dto: Option<DtoHint>,
+}
+
+#[derive(Debug, Deserialize, Clone, Default)]
+#[serde(deny_unknown_fields)]
+pub struct DtoHint {
+    #[serde(rename = "type")]
+    pub value_type: Option<String>,
+    pub description: Option<String>,
+    pub format: Option<String>,
+    #[serde(rename = "enum")]
+    pub enum_values: Option<Vec<EnumValue>>,
+    pub min: Option<f64>,
+    pub max: Option<f64>,
+    pub min_length: Option<u64>,
+    pub max_length: Option<u64>,
+    pub items: Option<Box<DtoHint>>,
+    pub values: Option<Box<DtoHint>>,
+    pub fields: Option<Vec<DtoField>>,
+    #[serde(default)]
+    pub always_emit: bool,
+    #[serde(default)]
+    pub deprecated: bool,
+    pub deprecation_message: Option<String>,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(untagged)]
+pub enum EnumValue {
+    Value(EnumLiteral),
+    Named { name: String, value: EnumLiteral },
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(untagged)]
+pub enum EnumLiteral {
+    Int(i64),
+    String(String),
+}
+
+impl EnumValue {
+    pub fn literal(&self) -> &EnumLiteral {
+        match self {
+            EnumValue::Value(value) | EnumValue::Named { value, .. } => value,
+        }
+    }
+
+    pub fn name(&self) -> Option<&str> {
+        match self {
+            EnumValue::Value(_) => None,
+            EnumValue::Named { name, .. } => Some(name),
+        }
+    }
+}
+
+#[derive(Debug, Deserialize, Clone)]
+#[serde(deny_unknown_fields)]
+pub struct DtoField {
+    pub name: String,
+    #[serde(rename = "type")]
+    pub value_type: Option<String>,
+    #[serde(default)]
+    pub required: bool,
+    pub dto: Option<DtoHint>,
 }
 
 #[derive(Debug, Deserialize, Clone)]
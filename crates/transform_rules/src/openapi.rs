@@ -0,0 +1,537 @@
+use std::collections::HashSet;
+
+use serde_json::{Map as JsonMap, Number as JsonNumber, Value as JsonValue};
+use serde_yaml::Value as YamlValue;
+
+use crate::dto::{
+    DtoError, Field, FieldConstraints, FieldType, PrimitiveType, SchemaNode, TupleType,
+    UnionType, UnionVariant,
+};
+use crate::model::{EnumLiteral, EnumValue};
+
+pub(crate) const SCHEMA_REF_PREFIX: &str = "#/components/schemas/";
+const MAX_TUPLE_ITEMS: usize = 16;
+
+pub(crate) fn build_openapi_schema(source: &str) -> Result<SchemaNode, DtoError> {
+    let document: YamlValue = serde_yaml::from_str(source)
+        .map_err(|err| DtoError::new(format!("invalid OpenAPI document: {}", err)))?;
+
+    let version = document
+        .get("openapi")
+        .and_then(|value| value.as_str())
+        .unwrap_or("");
+    if !version.starts_with("3.") {
+        return Err(DtoError::new("unsupported OpenAPI version (expected 3.x)"));
+    }
+
+    let schemas = document
+        .get("components")
+        .and_then(|components| components.get("schemas"))
+        .and_then(|schemas| schemas.as_mapping())
+        .ok_or_else(|| DtoError::new("OpenAPI document has no components/schemas"))?;
+
+    let mut components = Vec::new();
+    for (name, schema) in schemas.iter() {
+        let name = name
+            .as_str()
+            .ok_or_else(|| DtoError::new("schema name must be a string"))?;
+        components.push((name.to_string(), schema));
+    }
+    lower_components(&components)
+}
+
+pub(crate) fn lower_components(
+    components: &[(String, &YamlValue)],
+) -> Result<SchemaNode, DtoError> {
+    let lowering = Lowering { components };
+    let mut root = SchemaNode {
+        fields: Vec::new(),
+        doc: None,
+        additional_properties: false,
+        embeds: Vec::new(),
+    };
+    for name in lowering.ordered_components()? {
+        let schema = lowering.component(&name)?;
+        let mut node = lowering
+            .object_node(schema, &mut Vec::new())
+            .map_err(|err| err.with_field(&name))?;
+        node.doc = string_value(schema, "description");
+        root.fields.push(Field {
+            key: name,
+            field_type: FieldType::Object(Box::new(node)),
+            optional: false,
+            nullable: false,
+            always_emit: false,
+            deprecated: false,
+            deprecation_message: None,
+            format: None,
+            enum_values: None,
+            doc: None,
+            constraints: FieldConstraints::default(),
+            default: None,
+        });
+    }
+    Ok(root)
+}
+
+struct Lowering<'a> {
+    components: &'a [(String, &'a YamlValue)],
+}
+
+impl<'a> Lowering<'a> {
+    fn component(&self, name: &str) -> Result<&'a YamlValue, DtoError> {
+        self.components
+            .iter()
+            .find(|(key, _)| key == name)
+            .map(|(_, schema)| *schema)
+            .ok_or_else(|| DtoError::new(format!("unknown $ref: {}{}", SCHEMA_REF_PREFIX, name)))
+    }
+
+    fn ref_target(
+        &self,
+        schema: &YamlValue,
+    ) -> Result<Option<(String, &'a YamlValue)>, DtoError> {
+        let reference = match schema.get("$ref") {
+            Some(reference) => reference,
+            None => return Ok(None),
+        };
+        let reference = reference
+            .as_str()
+            .ok_or_else(|| DtoError::new("$ref must be a string"))?;
+        let name = reference
+            .strip_prefix(SCHEMA_REF_PREFIX)
+            .ok_or_else(|| DtoError::new(format!("unsupported $ref: {}", reference)))?;
+        Ok(Some((name.to_string(), self.component(name)?)))
+    }
+
+    fn ordered_components(&self) -> Result<Vec<String>, DtoError> {
+        let mut visited = HashSet::new();
+        let mut ordered = Vec::new();
+        for (name, _) in self.components {
+            self.visit_component(name, &mut visited, &mut ordered)?;
+        }
+        Ok(ordered)
+    }
+
+    fn visit_component(
+        &self,
+        name: &str,
+        visited: &mut HashSet<String>,
+        ordered: &mut Vec<String>,
+    ) -> Result<(), DtoError> {
+        if !visited.insert(name.to_string()) {
+            return Ok(());
+        }
+        let schema = self.component(name)?;
+        let mut refs = Vec::new();
+        collect_refs(schema, &mut refs);
+        for reference in refs {
+            if let Some(target) = reference.strip_prefix(SCHEMA_REF_PREFIX) {
+                if self.components.iter().any(|(key, _)| key == target) {
+                    self.visit_component(target, visited, ordered)?;
+                }
+            }
+        }
+        if is_object_schema(schema) {
+            ordered.push(name.to_string());
+        }
+        Ok(())
+    }
+
+    fn object_node(
+        &self,
+        schema: &'a YamlValue,
+        stack: &mut Vec<String>,
+    ) -> Result<SchemaNode, DtoError> {
+        let required: Vec<&str> = schema
+            .get("required")
+            .and_then(|required| required.as_sequence())
+            .map(|required| required.iter().filter_map(|key| key.as_str()).collect())
+            .unwrap_or_default();
+
+        let mut node = SchemaNode {
+            fields: Vec::new(),
+            doc: None,
+            additional_properties: allows_additional_properties(schema),
+            embeds: Vec::new(),
+        };
+        for part in schema
+            .get("allOf")
+            .and_then(|parts| parts.as_sequence())
+            .into_iter()
+            .flatten()
+        {
+            match self.ref_target(part)? {
+                Some((name, target)) if is_object_schema(target) => node.embeds.push(name),
+                Some((name, _)) => {
+                    return Err(DtoError::new(format!(
+                        "allOf member must be an object schema: {}",
+                        name
+                    )));
+                }
+                None => {
+                    let inline = self.object_node(part, stack)?;
+                    node.fields.extend(inline.fields);
+                    node.embeds.extend(inline.embeds);
+                    node.additional_properties |= inline.additional_properties;
+                }
+            }
+        }
+        if let Some(properties) = schema.get("properties").and_then(|value| value.as_mapping()) {
+            for (key, property) in properties.iter() {
+                let key = key
+                    .as_str()
+                    .ok_or_else(|| DtoError::new("property name must be a string"))?;
+                let field = self
+                    .lower_field(key, property, required.contains(&key), stack)
+                    .map_err(|err| err.with_field(key))?;
+                node.fields.push(field);
+            }
+        }
+        Ok(node)
+    }
+
+    fn lower_field(
+        &self,
+        key: &str,
+        property: &'a YamlValue,
+        required: bool,
+        stack: &mut Vec<String>,
+    ) -> Result<Field, DtoError> {
+        let field_type = self.lower_type(property, stack)?;
+        let resolved = self.resolve_scalar(property, &mut Vec::new())?;
+        let enum_values = match field_type {
+            FieldType::Primitive(PrimitiveType::Int) => enum_values(resolved, true)?,
+            FieldType::Primitive(PrimitiveType::String) => enum_values(resolved, false)?,
+            _ => None,
+        };
+        let constraints = FieldConstraints {
+            min: number_value(resolved, "minimum"),
+            max: number_value(resolved, "maximum"),
+            min_length: integer_value(resolved, "minLength")
+                .or_else(|| integer_value(resolved, "minItems")),
+            max_length: integer_value(resolved, "maxLength")
+                .or_else(|| integer_value(resolved, "maxItems")),
+        };
+        Ok(Field {
+            key: key.to_string(),
+            field_type,
+            optional: !required,
+            nullable: is_nullable(property) || is_nullable(resolved),
+            always_emit: false,
+            deprecated: bool_value(property, "deprecated") || bool_value(resolved, "deprecated"),
+            deprecation_message: None,
+            format: string_value(resolved, "format"),
+            enum_values,
+            doc: string_value(property, "description")
+                .or_else(|| string_value(resolved, "description")),
+            constraints,
+            default: property
+                .get("default")
+                .or_else(|| resolved.get("default"))
+                .and_then(json_value),
+        })
+    }
+
+    fn resolve_scalar(
+        &self,
+        schema: &'a YamlValue,
+        stack: &mut Vec<String>,
+    ) -> Result<&'a YamlValue, DtoError> {
+        match self.ref_target(schema)? {
+            Some((name, target)) if !is_object_schema(target) => {
+                if stack.contains(&name) {
+                    return Err(DtoError::new(format!("circular $ref: {}", name)));
+                }
+                stack.push(name);
+                let resolved = self.resolve_scalar(target, stack);
+                stack.pop();
+                resolved
+            }
+            _ => Ok(schema),
+        }
+    }
+
+    fn lower_type(
+        &self,
+        schema: &'a YamlValue,
+        stack: &mut Vec<String>,
+    ) -> Result<FieldType, DtoError> {
+        if let Some((name, target)) = self.ref_target(schema)? {
+            if is_object_schema(target) {
+                return Ok(FieldType::Ref(name));
+            }
+            if target.get("oneOf").is_some() {
+                return self.union_type(target, Some(name));
+            }
+            if stack.contains(&name) {
+                return Err(DtoError::new(format!("circular $ref: {}", name)));
+            }
+            stack.push(name.clone());
+            let lowered = if is_tuple_schema(target) {
+                self.tuple_type(target, Some(name), stack)
+            } else {
+                self.lower_type(target, stack)
+            };
+            stack.pop();
+            return lowered;
+        }
+        if schema.get("oneOf").is_some() {
+            return self.union_type(schema, None);
+        }
+        if is_tuple_schema(schema) {
+            return self.tuple_type(schema, None, stack);
+        }
+        if let Some(target) = single_all_of_ref(schema) {
+            return self.lower_type(target, stack);
+        }
+
+        match schema_type(schema) {
+            Some("string") => Ok(FieldType::Primitive(PrimitiveType::String)),
+            Some("integer") => Ok(FieldType::Primitive(PrimitiveType::Int)),
+            Some("number") => Ok(FieldType::Primitive(PrimitiveType::Float)),
+            Some("boolean") => Ok(FieldType::Primitive(PrimitiveType::Bool)),
+            Some("array") => match schema.get("items") {
+                Some(items) => Ok(FieldType::Array(Box::new(self.lower_type(items, stack)?))),
+                None => Ok(FieldType::Array(Box::new(FieldType::JsonValue))),
+            },
+            Some("object") | None if has_object_members(schema) => Ok(FieldType::Object(
+                Box::new(self.object_node(schema, stack)?),
+            )),
+            Some("object") | None => Ok(FieldType::JsonValue),
+            Some(other) => Err(DtoError::new(format!("unsupported schema type: {}", other))),
+        }
+    }
+
+    fn tuple_type(
+        &self,
+        schema: &'a YamlValue,
+        name: Option<String>,
+        stack: &mut Vec<String>,
+    ) -> Result<FieldType, DtoError> {
+        let (prefix, tail) = match schema.get("prefixItems") {
+            Some(prefix) => (Some(prefix), schema.get("items")),
+            None => (schema.get("items"), schema.get("additionalItems")),
+        };
+        let prefix = prefix
+            .and_then(|prefix| prefix.as_sequence())
+            .ok_or_else(|| DtoError::new("prefixItems must be a list"))?;
+        if tail.and_then(YamlValue::as_bool) != Some(false) {
+            return Err(DtoError::new(
+                "tuple with additional items is not supported (set items: false)",
+            ));
+        }
+        if prefix.is_empty() || prefix.len() > MAX_TUPLE_ITEMS {
+            return Err(DtoError::new(format!(
+                "tuple must have between 1 and {} items",
+                MAX_TUPLE_ITEMS
+            )));
+        }
+        let mut items = Vec::new();
+        for item in prefix {
+            let item = self.lower_type(item, stack)?;
+            if matches!(item, FieldType::Object(_)) {
+                return Err(DtoError::new("tuple items must not be inline objects; use $ref"));
+            }
+            items.push(item);
+        }
+        Ok(FieldType::Tuple(Box::new(TupleType { name, items })))
+    }
+
+    fn union_type(&self, schema: &YamlValue, name: Option<String>) -> Result<FieldType, DtoError> {
+        let variants = schema
+            .get("oneOf")
+            .and_then(|value| value.as_sequence())
+            .ok_or_else(|| DtoError::new("oneOf must be a list"))?;
+        let discriminator = schema.get("discriminator");
+        let mapping = discriminator
+            .and_then(|discriminator| discriminator.get("mapping"))
+            .and_then(|mapping| mapping.as_mapping());
+
+        let mut union = UnionType {
+            name,
+            discriminator: discriminator.and_then(|discriminator| {
+                string_value(discriminator, "propertyName")
+            }),
+            variants: Vec::new(),
+        };
+        for variant in variants {
+            let (type_name, target) = self
+                .ref_target(variant)?
+                .ok_or_else(|| DtoError::new("oneOf variants must be $ref"))?;
+            if !is_object_schema(target) {
+                return Err(DtoError::new(format!(
+                    "oneOf variant must be an object schema: {}",
+                    type_name
+                )));
+            }
+            let tag = mapping
+                .and_then(|mapping| {
+                    mapping.iter().find(|(_, reference)| match reference.as_str() {
+                        Some(reference) => {
+                            reference == type_name
+                                || reference.strip_prefix(SCHEMA_REF_PREFIX)
+                                    == Some(type_name.as_str())
+                        }
+                        None => false,
+                    })
+                })
+                .and_then(|(tag, _)| tag.as_str())
+                .map(|tag| tag.to_string())
+                .unwrap_or_else(|| type_name.clone());
+            union.variants.push(UnionVariant { tag, type_name });
+        }
+        Ok(FieldType::Union(Box::new(union)))
+    }
+}
+
+fn schema_type(schema: &YamlValue) -> Option<&str> {
+    match schema.get("type") {
+        Some(YamlValue::String(value)) => Some(value.as_str()),
+        Some(YamlValue::Sequence(values)) => values
+            .iter()
+            .filter_map(|value| value.as_str())
+            .find(|value| *value != "null"),
+        _ => None,
+    }
+}
+
+pub(crate) fn is_object_schema(schema: &YamlValue) -> bool {
+    schema.get("$ref").is_none()
+        && (schema_type(schema) == Some("object") || has_object_members(schema))
+}
+
+fn is_tuple_schema(schema: &YamlValue) -> bool {
+    schema.get("prefixItems").is_some()
+        || schema.get("items").is_some_and(|items| items.as_sequence().is_some())
+}
+
+fn has_object_members(schema: &YamlValue) -> bool {
+    schema.get("properties").is_some() || schema.get("allOf").is_some()
+}
+
+fn single_all_of_ref(schema: &YamlValue) -> Option<&YamlValue> {
+    match schema.get("allOf").and_then(|parts| parts.as_sequence()) {
+        Some(parts) if parts.len() == 1 && schema.get("properties").is_none() => {
+            parts.first().filter(|part| part.get("$ref").is_some())
+        }
+        _ => None,
+    }
+}
+
+fn allows_additional_properties(schema: &YamlValue) -> bool {
+    match schema.get("additionalProperties") {
+        Some(YamlValue::Bool(value)) => *value,
+        Some(YamlValue::Mapping(_)) => true,
+        _ => false,
+    }
+}
+
+fn is_nullable(schema: &YamlValue) -> bool {
+    if schema.get("nullable").and_then(|value| value.as_bool()) == Some(true) {
+        return true;
+    }
+    match schema.get("type") {
+        Some(YamlValue::Sequence(values)) => {
+            values.iter().any(|value| value.as_str() == Some("null"))
+        }
+        _ => false,
+    }
+}
+
+fn collect_refs(schema: &YamlValue, out: &mut Vec<String>) {
+    match schema {
+        YamlValue::Mapping(mapping) => {
+            for (key, value) in mapping.iter() {
+                if key.as_str() == Some("$ref") {
+                    if let Some(reference) = value.as_str() {
+                        out.push(reference.to_string());
+                    }
+                } else {
+                    collect_refs(value, out);
+                }
+            }
+        }
+        YamlValue::Sequence(values) => {
+            for value in values {
+                collect_refs(value, out);
+            }
+        }
+        _ => {}
+    }
+}
+
+fn string_value(schema: &YamlValue, key: &str) -> Option<String> {
+    schema
+        .get(key)
+        .and_then(|value| value.as_str())
+        .map(|value| value.to_string())
+}
+
+fn bool_value(schema: &YamlValue, key: &str) -> bool {
+    schema.get(key).and_then(|value| value.as_bool()) == Some(true)
+}
+
+fn number_value(schema: &YamlValue, key: &str) -> Option<f64> {
+    schema.get(key).and_then(|value| value.as_f64())
+}
+
+fn integer_value(schema: &YamlValue, key: &str) -> Option<u64> {
+    schema.get(key).and_then(|value| value.as_u64())
+}
+
+fn json_value(value: &YamlValue) -> Option<JsonValue> {
+    match value {
+        YamlValue::Null => Some(JsonValue::Null),
+        YamlValue::Bool(value) => Some(JsonValue::Bool(*value)),
+        YamlValue::Number(_) => value
+            .as_i64()
+            .map(JsonValue::from)
+            .or_else(|| value.as_u64().map(JsonValue::from))
+            .or_else(|| value.as_f64().and_then(JsonNumber::from_f64).map(JsonValue::Number)),
+        YamlValue::String(value) => Some(JsonValue::String(value.clone())),
+        YamlValue::Sequence(values) => values.iter().map(json_value).collect::<Option<_>>(),
+        YamlValue::Mapping(mapping) => mapping
+            .iter()
+            .map(|(key, value)| Some((key.as_str()?.to_string(), json_value(value)?)))
+            .collect::<Option<JsonMap<_, _>>>()
+            .map(JsonValue::Object),
+        _ => None,
+    }
+}
+
+fn enum_values(schema: &YamlValue, integer: bool) -> Result<Option<Vec<EnumValue>>, DtoError> {
+    let Some(values) = schema.get("enum").and_then(|values| values.as_sequence()) else {
+        return Ok(None);
+    };
+    if !values.is_empty() && values.iter().all(|value| value.is_null()) {
+        return Ok(None);
+    }
+    let names = schema
+        .get("x-enum-varnames")
+        .and_then(|names| names.as_sequence());
+    values
+        .iter()
+        .enumerate()
+        .filter(|(_, value)| !value.is_null())
+        .map(|(index, value)| {
+            let literal = match (value.as_i64(), value.as_str()) {
+                (Some(value), _) => EnumLiteral::Int(value),
+                (_, Some(value)) => EnumLiteral::String(value.to_string()),
+                _ if integer => return Err(DtoError::new("enum value must be an integer")),
+                _ => return Err(DtoError::new("enum value must be a string")),
+            };
+            let name = names
+                .and_then(|names| names.get(index))
+                .and_then(|name| name.as_str());
+            Ok(match name {
+                Some(name) => EnumValue::Named {
+                    name: name.to_string(),
+                    value: literal,
+                },
+                None => EnumValue::Value(literal),
+            })
+        })
+        .collect::<Result<_, _>>()
+        .map(Some)
+}
@@ -0,0 +1,135 @@
+use std::collections::HashSet;
+
+use crate::dto::{DtoError, DtoSchema, Field, FieldType, PrimitiveType, SchemaNode, TypeRoot};
+use crate::model::EnumLiteral;
+
+pub fn validate_dto_schema(schema: &DtoSchema) -> Vec<DtoError> {
+    schema_errors(&schema.schema, schema.root.as_type_root())
+}
+
+pub(crate) fn schema_errors(schema: &SchemaNode, root: TypeRoot) -> Vec<DtoError> {
+    let mut ctx = SchemaCtx {
+        types: HashSet::new(),
+        errors: Vec::new(),
+    };
+    match root {
+        TypeRoot::Named(name) => validate_node(schema, name, &mut ctx),
+        TypeRoot::Components => {
+            ctx.types = schema
+                .fields
+                .iter()
+                .map(|field| field.key.as_str())
+                .collect();
+            validate_duplicates(schema, None, &mut ctx);
+            for field in &schema.fields {
+                validate_field_type(&field.field_type, &field.key, &mut ctx);
+            }
+        }
+    }
+    ctx.errors
+}
+
+struct SchemaCtx<'a> {
+    types: HashSet<&'a str>,
+    errors: Vec<DtoError>,
+}
+
+fn validate_node<'a>(node: &'a SchemaNode, path: &str, ctx: &mut SchemaCtx<'a>) {
+    if node.fields.is_empty() && node.embeds.is_empty() && !node.additional_properties {
+        ctx.errors
+            .push(DtoError::new("object has no properties").with_field(path));
+    }
+    for embed in &node.embeds {
+        validate_ref(embed, path, ctx);
+    }
+    validate_duplicates(node, Some(path), ctx);
+    for field in &node.fields {
+        let field_path = format!("{}.{}", path, field.key);
+        validate_enum(field, &field_path, ctx);
+        validate_field_type(&field.field_type, &field_path, ctx);
+    }
+}
+
+fn validate_enum(field: &Field, path: &str, ctx: &mut SchemaCtx<'_>) {
+    let Some(values) = &field.enum_values else {
+        return;
+    };
+    if values.is_empty() {
+        ctx.errors
+            .push(DtoError::new("enum has no values").with_field(path));
+    }
+    for value in values {
+        let message = match (&field.field_type, value.literal()) {
+            (FieldType::Primitive(PrimitiveType::String), EnumLiteral::Int(_)) => {
+                Some("enum value must be a string".to_string())
+            }
+            (FieldType::Primitive(PrimitiveType::Int), EnumLiteral::String(_)) => {
+                Some("enum value must be an integer".to_string())
+            }
+            _ => value
+                .name()
+                .filter(|name| !is_identifier(name))
+                .map(|name| format!("enum name {} is not a valid identifier", name)),
+        };
+        if let Some(message) = message {
+            ctx.errors.push(DtoError::new(message).with_field(path));
+        }
+    }
+}
+
+fn is_identifier(name: &str) -> bool {
+    let mut chars = name.chars();
+    chars
+        .next()
+        .is_some_and(|ch| ch.is_ascii_alphabetic() || ch == '_')
+        && chars.all(|ch| ch.is_ascii_alphanumeric() || ch == '_')
+}
+
+fn validate_duplicates(node: &SchemaNode, path: Option<&str>, ctx: &mut SchemaCtx<'_>) {
+    let mut seen = HashSet::new();
+    for field in &node.fields {
+        if !seen.insert(field.key.as_str()) {
+            let field_path = match path {
+                Some(path) => format!("{}.{}", path, field.key),
+                None => field.key.clone(),
+            };
+            ctx.errors
+                .push(DtoError::new("duplicate property").with_field(&field_path));
+        }
+    }
+}
+
+fn validate_field_type<'a>(field_type: &'a FieldType, path: &str, ctx: &mut SchemaCtx<'a>) {
+    match field_type {
+        FieldType::Object(child) => validate_node(child, path, ctx),
+        FieldType::Array(item) | FieldType::Map(item) => validate_field_type(item, path, ctx),
+        FieldType::Ref(name) => validate_ref(name, path, ctx),
+        FieldType::Union(union) => {
+            for variant in &union.variants {
+                validate_ref(&variant.type_name, path, ctx);
+            }
+        }
+        FieldType::Tuple(tuple) => {
+            if tuple.items.is_empty() {
+                ctx.errors
+                    .push(DtoError::new("tuple has no items").with_field(path));
+            }
+            for item in &tuple.items {
+                if matches!(item, FieldType::Object(_)) {
+                    ctx.errors.push(
+                        DtoError::new("tuple item must not be an inline object").with_field(path),
+                    );
+                }
+                validate_field_type(item, path, ctx);
+            }
+        }
+        FieldType::Primitive(_) | FieldType::JsonValue => {}
+    }
+}
+
+fn validate_ref(name: &str, path: &str, ctx: &mut SchemaCtx<'_>) {
+    if !ctx.types.contains(name) {
+        ctx.errors
+            .push(DtoError::new(format!("reference to undefined type {}", name)).with_field(path));
+    }
+}
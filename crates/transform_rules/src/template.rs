@@ -0,0 +1,270 @@
+use serde_json::Value as JsonValue;
+
+use crate::dto::DtoError;
+
+pub(crate) fn render_template(template: &str, context: &JsonValue) -> Result<String, DtoError> {
+    let mut tokens = tokenize(template)?.into_iter();
+    let (nodes, end) = parse_nodes(&mut tokens)?;
+    if let Some(end) = end {
+        return Err(template_error(format!("unexpected {}", end)));
+    }
+    let mut out = String::new();
+    let mut scopes = vec![Scope {
+        value: context,
+        index: None,
+    }];
+    render_nodes(&nodes, &mut scopes, &mut out)?;
+    Ok(out)
+}
+
+enum Token {
+    Text(String),
+    Var(String),
+    Open(String, String),
+    Else,
+    Close(String),
+    Comment,
+}
+
+enum Node {
+    Text(String),
+    Var(String),
+    Block {
+        helper: String,
+        arg: String,
+        body: Vec<Node>,
+        inverse: Vec<Node>,
+    },
+}
+
+struct Scope<'a> {
+    value: &'a JsonValue,
+    index: Option<(usize, usize)>,
+}
+
+fn template_error(message: impl Into<String>) -> DtoError {
+    DtoError::new(format!("invalid template: {}", message.into()))
+}
+
+fn tokenize(template: &str) -> Result<Vec<Token>, DtoError> {
+    let mut tokens = Vec::new();
+    let mut rest = template;
+    let mut line_start = true;
+    while let Some(start) = rest.find("{{") {
+        let end = rest[start..]
+            .find("}}")
+            .map(|end| start + end)
+            .ok_or_else(|| template_error("unclosed {{"))?;
+        let token = parse_tag(rest[start + 2..end].trim())?;
+        let mut text = &rest[..start];
+        let mut after = &rest[end + 2..];
+        let mut standalone = false;
+        if !matches!(token, Token::Var(_)) {
+            let (prefix_start, prefix_blank) = match text.rfind('\n') {
+                Some(newline) => (newline + 1, text[newline + 1..].trim().is_empty()),
+                None => (0, line_start && text.trim().is_empty()),
+            };
+            let line_end = after.find('\n');
+            let suffix = &after[..line_end.unwrap_or(after.len())];
+            if prefix_blank && suffix.trim().is_empty() {
+                text = &text[..prefix_start];
+                after = &after[line_end.map_or(after.len(), |newline| newline + 1)..];
+                standalone = true;
+            }
+        }
+        if !text.is_empty() {
+            tokens.push(Token::Text(text.to_string()));
+        }
+        tokens.push(token);
+        line_start = standalone;
+        rest = after;
+    }
+    if !rest.is_empty() {
+        tokens.push(Token::Text(rest.to_string()));
+    }
+    Ok(tokens)
+}
+
+fn parse_tag(tag: &str) -> Result<Token, DtoError> {
+    if tag.starts_with('!') {
+        return Ok(Token::Comment);
+    }
+    if tag == "else" {
+        return Ok(Token::Else);
+    }
+    if let Some(name) = tag.strip_prefix('/') {
+        return Ok(Token::Close(name.trim().to_string()));
+    }
+    if let Some(block) = tag.strip_prefix('#') {
+        let mut parts = block.split_whitespace();
+        let helper = parts.next().unwrap_or("");
+        if !matches!(helper, "each" | "if" | "unless") {
+            return Err(template_error(format!("unknown helper: {}", helper)));
+        }
+        let arg = match (parts.next(), parts.next()) {
+            (Some(arg), None) => arg,
+            _ => {
+                return Err(template_error(format!(
+                    "{{{{#{}}}}} takes one argument",
+                    helper
+                )));
+            }
+        };
+        return Ok(Token::Open(helper.to_string(), arg.to_string()));
+    }
+    if tag.is_empty() {
+        return Err(template_error("empty {{}}"));
+    }
+    Ok(Token::Var(tag.to_string()))
+}
+
+fn parse_nodes(
+    tokens: &mut impl Iterator<Item = Token>,
+) -> Result<(Vec<Node>, Option<String>), DtoError> {
+    let mut nodes = Vec::new();
+    while let Some(token) = tokens.next() {
+        match token {
+            Token::Text(text) => nodes.push(Node::Text(text)),
+            Token::Var(path) => nodes.push(Node::Var(path)),
+            Token::Comment => {}
+            Token::Else => return Ok((nodes, Some("{{else}}".to_string()))),
+            Token::Close(name) => return Ok((nodes, Some(format!("{{{{/{}}}}}", name)))),
+            Token::Open(helper, arg) => {
+                let close = format!("{{{{/{}}}}}", helper);
+                let (body, end) = parse_nodes(tokens)?;
+                let (inverse, end) = match end {
+                    Some(end) if end == "{{else}}" => parse_nodes(tokens)?,
+                    end => (Vec::new(), end),
+                };
+                match end {
+                    Some(end) if end == close => {}
+                    Some(end) => return Err(template_error(format!("unexpected {}", end))),
+                    None => return Err(template_error(format!("unclosed {{{{#{}}}}}", helper))),
+                }
+                nodes.push(Node::Block {
+                    helper,
+                    arg,
+                    body,
+                    inverse,
+                });
+            }
+        }
+    }
+    Ok((nodes, None))
+}
+
+fn render_nodes<'a>(
+    nodes: &'a [Node],
+    scopes: &mut Vec<Scope<'a>>,
+    out: &mut String,
+) -> Result<(), DtoError> {
+    for node in nodes {
+        match node {
+            Node::Text(text) => out.push_str(text),
+            Node::Var(path) => match lookup(path, scopes)? {
+                Lookup::Value(JsonValue::Null) => {}
+                Lookup::Value(JsonValue::String(value)) => out.push_str(value),
+                Lookup::Value(value @ (JsonValue::Bool(_) | JsonValue::Number(_))) => {
+                    out.push_str(&value.to_string())
+                }
+                Lookup::Value(_) => {
+                    return Err(template_error(format!("{} is not a scalar value", path)));
+                }
+                Lookup::Meta(value) => out.push_str(&value.to_string()),
+            },
+            Node::Block {
+                helper,
+                arg,
+                body,
+                inverse,
+            } => {
+                let value = match lookup(arg, scopes)? {
+                    Lookup::Value(value) => value,
+                    Lookup::Meta(value) => {
+                        let branch = truthy(&value) != (helper == "unless");
+                        render_nodes(if branch { body } else { inverse }, scopes, out)?;
+                        continue;
+                    }
+                };
+                match helper.as_str() {
+                    "each" => match value {
+                        JsonValue::Array(items) if !items.is_empty() => {
+                            for (index, item) in items.iter().enumerate() {
+                                scopes.push(Scope {
+                                    value: item,
+                                    index: Some((index, items.len())),
+                                });
+                                let result = render_nodes(body, scopes, out);
+                                scopes.pop();
+                                result?;
+                            }
+                        }
+                        JsonValue::Array(_) | JsonValue::Null => {
+                            render_nodes(inverse, scopes, out)?
+                        }
+                        _ => return Err(template_error(format!("{} is not a list", arg))),
+                    },
+                    _ => {
+                        let branch = truthy(value) != (helper == "unless");
+                        render_nodes(if branch { body } else { inverse }, scopes, out)?;
+                    }
+                }
+            }
+        }
+    }
+    Ok(())
+}
+
+enum Lookup<'a> {
+    Value(&'a JsonValue),
+    Meta(JsonValue),
+}
+
+fn lookup<'a>(path: &str, scopes: &[Scope<'a>]) -> Result<Lookup<'a>, DtoError> {
+    let current = scopes.last().expect("template scope");
+    if let Some(meta) = path.strip_prefix('@') {
+        let (index, len) = current
+            .index
+            .ok_or_else(|| template_error(format!("@{} used outside {{{{#each}}}}", meta)))?;
+        return match meta {
+            "index" => Ok(Lookup::Meta(JsonValue::from(index))),
+            "first" => Ok(Lookup::Meta(JsonValue::Bool(index == 0))),
+            "last" => Ok(Lookup::Meta(JsonValue::Bool(index + 1 == len))),
+            _ => Err(template_error(format!("unknown variable: {}", path))),
+        };
+    }
+    if path == "this" || path == "." {
+        return Ok(Lookup::Value(current.value));
+    }
+    let (scopes, path) = match path.strip_prefix("this.") {
+        Some(rest) => (&scopes[scopes.len() - 1..], rest),
+        None => (scopes, path),
+    };
+    let mut segments = path.split('.');
+    let first = segments.next().unwrap_or("");
+    let mut value = scopes
+        .iter()
+        .rev()
+        .find_map(|scope| scope.value.get(first))
+        .ok_or_else(|| template_error(format!("unknown variable: {}", path)))?;
+    for segment in segments {
+        value = match value {
+            JsonValue::Null => return Ok(Lookup::Value(value)),
+            _ => value
+                .get(segment)
+                .ok_or_else(|| template_error(format!("unknown variable: {}", path)))?,
+        };
+    }
+    Ok(Lookup::Value(value))
+}
+
+fn truthy(value: &JsonValue) -> bool {
+    match value {
+        JsonValue::Null => false,
+        JsonValue::Bool(value) => *value,
+        JsonValue::Number(value) => value.as_f64() != Some(0.0),
+        JsonValue::String(value) => !value.is_empty(),
+        JsonValue::Array(items) => !items.is_empty(),
+        JsonValue::Object(_) => true,
+    }
+}
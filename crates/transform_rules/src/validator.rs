@@ -2,7 +2,9 @@ use std::collections::HashSet;
 
 use crate::error::{ErrorCode, RuleError, ValidationResult};
 use crate::locator::YamlLocator;
-use crate::model::{Expr, ExprChain, ExprOp, ExprRef, InputFormat, Mapping, RuleFile};
+use crate::model::{
+    DtoHint, Expr, ExprChain, ExprOp, ExprRef, InputFormat, Mapping, RuleFile,
+};
 use crate::path::{parse_path, PathToken};
 
 pub fn validate_rule_file(rule: &RuleFile) -> ValidationResult {
@@ -165,6 +167,10 @@ fn validate_mappings(rule: &RuleFile, ctx: &mut ValidationCtx<'_>) {
             }
         }
 
+        if let Some(hint) = &mapping.dto {
+            validate_dto_hint(hint, &format!("{}.dto", base), ctx);
+        }
+
         if let Some(source) = &mapping.source {
             validate_source(source, &base, &produced_targets, ctx);
         }
@@ -184,6 +190,44 @@ fn validate_mappings(rule: &RuleFile, ctx: &mut ValidationCtx<'_>) {
     }
 }
 
+fn validate_dto_hint(hint: &DtoHint, base_path: &str, ctx: &mut ValidationCtx<'_>) {
+    if let Some(type_name) = &hint.value_type {
+        if !is_valid_type_name(type_name) {
+            ctx.push(
+                ErrorCode::InvalidTypeName,
+                "type must be string|int|float|bool",
+                format!("{}.type", base_path),
+            );
+        }
+    }
+
+    if let Some(items) = &hint.items {
+        validate_dto_hint(items, &format!("{}.items", base_path), ctx);
+    }
+
+    if let Some(values) = &hint.values {
+        validate_dto_hint(values, &format!("{}.values", base_path), ctx);
+    }
+
+    if let Some(fields) = &hint.fields {
+        for (index, field) in fields.iter().enumerate() {
+            let field_path = format!("{}.fields[{}]", base_path, index);
+            if let Some(type_name) = &field.value_type {
+                if !is_valid_type_name(type_name) {
+                    ctx.push(
+                        ErrorCode::InvalidTypeName,
+                        "type must be string|int|float|bool",
+                        format!("{}.type", field_path),
+                    );
+                }
+            }
+            if let Some(field_hint) = &field.dto {
+                validate_dto_hint(field_hint, &format!("{}.dto", field_path), ctx);
+            }
+        }
+    }
+}
+
 fn count_value_fields(mapping: &Mapping) -> usize {
     let mut count = 0;
     if mapping.source.is_some() {
@@ -1,7 +1,16 @@
 use std::fs;
+use std::io::{ErrorKind, Write};
 use std::path::{Path, PathBuf};
+use std::process::{Command, Stdio};
 
-use transform_rules::{generate_dto, parse_rule_file, DtoLanguage};
+use transform_rules::{
+    build_dto_schema, diff_generated_file, generate_dto, generate_dto_batch,
+    generate_dto_batch_with_jobs, generate_dto_from_json_schema, generate_dto_from_openapi,
+    generate_dto_from_openapi_with_warnings, generate_dto_from_schema, generate_dto_with_options,
+    generate_dto_with_warnings, parse_rule_file, unified_diff, validate_dto_schema,
+    AdditionalPropertiesPolicy, BatchMode, DtoLanguage, DtoOptions, DtoSchema, FieldOrder,
+    GoHeader, GoIndent, GoType, OptionalStrategy, TagNamingStrategy,
+};
 
 fn fixtures_dir() -> PathBuf {
     Path::new(env!("CARGO_MANIFEST_DIR"))
@@ -25,13 +34,56 @@ fn load_text(path: &Path) -> String {
 }
 
 fn assert_golden(lang: DtoLanguage, expected: &str) {
-    let base = fixtures_dir().join("dto01_basic");
+    assert_golden_case("dto01_basic", lang, expected);
+}
+
+fn assert_golden_case(case: &str, lang: DtoLanguage, expected: &str) {
+    let base = fixtures_dir().join(case);
     let rule = load_rule(&base.join("rules.yaml"));
     let output = generate_dto(&rule, lang, None).expect("dto failed");
     let expected = load_text(&base.join(expected));
     assert_eq!(output, expected);
 }
 
+fn assert_golden_with_options(
+    case: &str,
+    lang: DtoLanguage,
+    options: &DtoOptions,
+    expected: &str,
+) {
+    let base = fixtures_dir().join(case);
+    let rule = load_rule(&base.join("rules.yaml"));
+    let output = generate_dto_with_options(&rule, lang, None, options).expect("dto failed");
+    let expected = load_text(&base.join(expected));
+    assert_eq!(output, expected);
+}
+
+fn assert_openapi_golden(case: &str, lang: DtoLanguage, expected: &str) {
+    assert_openapi_golden_with_options(case, lang, &DtoOptions::default(), expected);
+}
+
+fn assert_openapi_golden_with_options(
+    case: &str,
+    lang: DtoLanguage,
+    options: &DtoOptions,
+    expected: &str,
+) {
+    let base = fixtures_dir().join(case);
+    let source = load_text(&base.join("openapi.yaml"));
+    let output = generate_dto_from_openapi(&source, lang, options).expect("dto failed");
+    let expected = load_text(&base.join(expected));
+    assert_eq!(output, expected);
+}
+
+fn assert_json_schema_golden(case: &str, lang: DtoLanguage, expected: &str) {
+    let base = fixtures_dir().join(case);
+    let path = base.join("schema.json");
+    let output = generate_dto_from_json_schema(&path, lang, None, &DtoOptions::default())
+        .expect("dto failed");
+    let expected = load_text(&base.join(expected));
+    assert_eq!(output, expected);
+}
+
 #[test]
 fn dto01_rust() {
     assert_golden(DtoLanguage::Rust, "expected_rust.rs");
@@ -47,6 +99,11 @@ fn dto01_python() {
     assert_golden(DtoLanguage::Python, "expected_python.py");
 }
 
+#[test]
+fn dto01_pydantic() {
+    assert_golden(DtoLanguage::Pydantic, "expected_pydantic.py");
+}
+
 #[test]
 fn dto01_go() {
     assert_golden(DtoLanguage::Go, "expected_go.go");
@@ -66,3 +123,1663 @@ fn dto01_kotlin() {
 fn dto01_swift() {
     assert_golden(DtoLanguage::Swift, "expected_swift.swift");
 }
+
+#[test]
+fn dto01_protobuf() {
+    assert_golden(DtoLanguage::Protobuf, "expected_protobuf.proto");
+}
+
+#[test]
+fn dto01_json_schema() {
+    assert_golden(DtoLanguage::JsonSchema, "expected_json_schema.json");
+}
+
+#[test]
+fn dto01_json_schema_round_trip() {
+    let base = fixtures_dir().join("dto01_basic");
+    let rule = load_rule(&base.join("rules.yaml"));
+    let schema = generate_dto(&rule, DtoLanguage::JsonSchema, None).expect("dto failed");
+
+    let dir = std::env::temp_dir().join(format!("dto01_json_schema_{}", std::process::id()));
+    fs::create_dir_all(&dir).expect("create temp dir");
+    let path = dir.join("record.schema.json");
+    fs::write(&path, schema).expect("write schema");
+    let output =
+        generate_dto_from_json_schema(&path, DtoLanguage::Go, None, &DtoOptions::default())
+            .expect("dto failed");
+    let _ = fs::remove_dir_all(&dir);
+
+    assert_eq!(output, load_text(&base.join("expected_go.go")));
+}
+
+#[test]
+fn dto02_go_format_types() {
+    assert_golden_case("dto02_go_format_types", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto02_go_custom_format_types() {
+    let mut options = DtoOptions::default();
+    options.go.format_types.insert(
+        "date".to_string(),
+        GoType::new("civil.Date").with_import("cloud.google.com/go/civil"),
+    );
+    assert_golden_with_options(
+        "dto02_go_format_types",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_custom.go",
+    );
+}
+
+fn assert_go_tag_naming(strategy: TagNamingStrategy, expected: &str) {
+    let mut options = DtoOptions::default();
+    options.go.tag_naming = strategy;
+    assert_golden_with_options("dto03_go_tag_naming", DtoLanguage::Go, &options, expected);
+}
+
+#[test]
+fn dto03_go_tag_naming_as_is() {
+    assert_go_tag_naming(TagNamingStrategy::AsIs, "expected_go_as_is.go");
+}
+
+#[test]
+fn dto03_go_tag_naming_snake_case() {
+    assert_go_tag_naming(TagNamingStrategy::SnakeCase, "expected_go_snake_case.go");
+}
+
+#[test]
+fn dto03_go_tag_naming_camel_case() {
+    assert_go_tag_naming(TagNamingStrategy::CamelCase, "expected_go_camel_case.go");
+}
+
+#[test]
+fn dto03_go_tag_naming_pascal_case() {
+    assert_go_tag_naming(TagNamingStrategy::PascalCase, "expected_go_pascal_case.go");
+}
+
+#[test]
+fn dto04_go_enums_disabled() {
+    assert_golden_case("dto04_go_enums", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto04_go_enums() {
+    let mut options = DtoOptions::default();
+    options.go.emit_enums = true;
+    assert_golden_with_options("dto04_go_enums", DtoLanguage::Go, &options, "expected_go_enums.go");
+}
+
+#[test]
+fn dto29_go_enum_names_and_integer_values() {
+    let mut options = DtoOptions::default();
+    options.go.emit_enums = true;
+    options.go.emit_constructors = true;
+    assert_golden_with_options(
+        "dto29_go_enum_names",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_enums.go",
+    );
+}
+
+#[test]
+fn dto29_openapi_enum_varnames() {
+    let source = "openapi: 3.1.0\ncomponents:\n  schemas:\n    Job:\n      type: object\n      \
+                  properties:\n        priority:\n          type: integer\n          \
+                  enum: [1, 2]\n          x-enum-varnames: [PriorityLow, PriorityHigh]\n";
+    let mut options = DtoOptions::default();
+    options.go.emit_enums = true;
+    let output = generate_dto_from_openapi(source, DtoLanguage::Go, &options).unwrap();
+    assert!(output.contains("type JobPriority int64\n"));
+    assert!(output.contains("\tPriorityLow  JobPriority = 1\n\tPriorityHigh JobPriority = 2\n"));
+}
+
+#[test]
+fn dto29_openapi_enum_values_must_match_field_type() {
+    let source = "openapi: 3.1.0\ncomponents:\n  schemas:\n    Job:\n      type: object\n      \
+                  properties:\n        priority:\n          type: integer\n          \
+                  enum: [1, \"two\"]\n";
+    let err = generate_dto_from_openapi(source, DtoLanguage::Go, &DtoOptions::default())
+        .unwrap_err();
+    assert_eq!(err.to_string(), "Job.priority: enum value must be an integer");
+
+    let source = "openapi: 3.1.0\ncomponents:\n  schemas:\n    Job:\n      type: object\n      \
+                  properties:\n        state:\n          type: string\n          \
+                  enum: [\"open\", true]\n";
+    let err = generate_dto_from_openapi(source, DtoLanguage::Go, &DtoOptions::default())
+        .unwrap_err();
+    assert_eq!(err.to_string(), "Job.state: enum value must be a string");
+}
+
+#[test]
+fn dto29_openapi_null_only_enum_is_ignored() {
+    let source = "openapi: 3.1.0\ncomponents:\n  schemas:\n    Job:\n      type: object\n      \
+                  properties:\n        note:\n          type: string\n          \
+                  nullable: true\n          enum: [null]\n";
+    let mut options = DtoOptions::default();
+    options.go.emit_enums = true;
+    let output = generate_dto_from_openapi(source, DtoLanguage::Go, &options).unwrap();
+    assert!(output.contains("\tNote *string `json:\"note,omitempty\"`\n"));
+    assert!(!output.contains("type JobNote"));
+}
+
+#[test]
+fn dto29_go_enum_rejects_constant_collisions() {
+    let rule = parse_rule_file(
+        "version: 1\ninput:\n  format: json\nmappings:\n  - target: \"status\"\n    \
+         source: \"status\"\n    type: \"string\"\n    dto:\n      \
+         enum: [\"in-progress\", \"in_progress\"]\n",
+    )
+    .unwrap();
+    let mut options = DtoOptions::default();
+    options.go.emit_enums = true;
+    let err = generate_dto_with_options(&rule, DtoLanguage::Go, None, &options).unwrap_err();
+    assert_eq!(
+        err.to_string(),
+        "Record.status: enum constant RecordStatusInProgress is defined more than once"
+    );
+}
+
+#[test]
+fn dto29_enum_values_must_match_field_type() {
+    let rule = parse_rule_file(
+        "version: 1\ninput:\n  format: json\nmappings:\n  - target: \"code\"\n    \
+         source: \"code\"\n    type: \"int\"\n    dto:\n      \
+         enum: [1, \"two\", {name: \"3x\", value: 3}]\n",
+    )
+    .unwrap();
+    let schema = build_dto_schema(&rule, None).unwrap();
+    let errors: Vec<String> = validate_dto_schema(&schema)
+        .iter()
+        .map(|err| err.to_string())
+        .collect();
+    assert_eq!(
+        errors,
+        vec![
+            "Record.code: enum value must be an integer",
+            "Record.code: enum name 3x is not a valid identifier",
+        ]
+    );
+}
+
+#[test]
+fn dto30_go_deprecated_fields() {
+    assert_golden_case("dto30_deprecated", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto30_typescript_deprecated_fields() {
+    assert_golden_case("dto30_deprecated", DtoLanguage::TypeScript, "expected_typescript.ts");
+}
+
+#[test]
+fn dto30_java_deprecated_fields() {
+    assert_golden_case("dto30_deprecated", DtoLanguage::Java, "expected_java.java");
+}
+
+#[test]
+fn dto30_kotlin_deprecated_fields() {
+    assert_golden_case("dto30_deprecated", DtoLanguage::Kotlin, "expected_kotlin.kt");
+}
+
+#[test]
+fn dto30_protobuf_deprecated_fields() {
+    assert_golden_case("dto30_deprecated", DtoLanguage::Protobuf, "expected_protobuf.proto");
+}
+
+#[test]
+fn dto30_json_schema_deprecated_fields() {
+    assert_golden_case("dto30_deprecated", DtoLanguage::JsonSchema, "expected_json_schema.json");
+}
+
+#[test]
+fn dto30_rust_deprecated_fields() {
+    assert_golden_case("dto30_deprecated", DtoLanguage::Rust, "expected_rust.rs");
+}
+
+#[test]
+fn dto30_swift_deprecated_fields() {
+    assert_golden_case("dto30_deprecated", DtoLanguage::Swift, "expected_swift.swift");
+}
+
+#[test]
+fn dto30_pydantic_deprecated_fields() {
+    assert_golden_case("dto30_deprecated", DtoLanguage::Pydantic, "expected_pydantic.py");
+}
+
+#[test]
+fn dto30_python_deprecated_fields() {
+    assert_golden_case("dto30_deprecated", DtoLanguage::Python, "expected_python.py");
+}
+
+#[test]
+fn dto30_openapi_deprecated_property() {
+    let source = "openapi: 3.1.0\ncomponents:\n  schemas:\n    User:\n      type: object\n      \
+                  properties:\n        login:\n          type: string\n          \
+                  description: Sign-in name.\n          deprecated: true\n";
+    let output =
+        generate_dto_from_openapi(source, DtoLanguage::Go, &DtoOptions::default()).unwrap();
+    assert!(output.contains("\t// Sign-in name.\n\t//\n\t// Deprecated: Do not use.\n\tLogin "));
+}
+
+#[test]
+fn dto07_go_docs() {
+    assert_golden_case("dto07_go_docs", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto01_go_optional_value_with_omitempty() {
+    let mut options = DtoOptions::default();
+    options.go.optional_strategy = OptionalStrategy::ValueWithOmitempty;
+    assert_golden_with_options(
+        "dto01_basic",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_value_omitempty.go",
+    );
+}
+
+#[test]
+fn dto01_go_optional_always_pointer() {
+    let mut options = DtoOptions::default();
+    options.go.optional_strategy = OptionalStrategy::AlwaysPointer;
+    assert_golden_with_options(
+        "dto01_basic",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_always_pointer.go",
+    );
+}
+
+#[test]
+fn dto08_go_validation_disabled() {
+    assert_golden_case("dto08_go_validation", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto08_go_validation() {
+    let mut options = DtoOptions::default();
+    options.go.emit_validation = true;
+    assert_golden_with_options(
+        "dto08_go_validation",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_validation.go",
+    );
+}
+
+#[test]
+fn dto08_go_validate_method() {
+    let mut options = DtoOptions::default();
+    options.go.emit_validate_method = true;
+    assert_golden_with_options(
+        "dto08_go_validation",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_validate_method.go",
+    );
+}
+
+#[test]
+fn dto08_go_validate_method_checks_required_pointers() {
+    let mut options = DtoOptions::default();
+    options.go.emit_validate_method = true;
+    options.go.optional_strategy = OptionalStrategy::AlwaysPointer;
+    assert_golden_with_options(
+        "dto08_go_validation",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_validate_method_always_pointer.go",
+    );
+}
+
+#[test]
+fn dto01_go_validate_method_skips_types_without_constraints() {
+    let mut options = DtoOptions::default();
+    options.go.emit_validate_method = true;
+    assert_golden_with_options("dto01_basic", DtoLanguage::Go, &options, "expected_go.go");
+}
+
+#[test]
+fn dto01_go_package_name() {
+    let mut options = DtoOptions::default();
+    options.go.package_name = "models".to_string();
+    assert_golden_with_options("dto01_basic", DtoLanguage::Go, &options, "expected_go_package.go");
+}
+
+#[test]
+fn dto01_go_rejects_invalid_package_name() {
+    let rule = load_rule(&fixtures_dir().join("dto01_basic").join("rules.yaml"));
+    for name in ["", "my-models", "1models", "type", "_"] {
+        let mut options = DtoOptions::default();
+        options.go.package_name = name.to_string();
+        let result = generate_dto_with_options(&rule, DtoLanguage::Go, None, &options);
+        assert!(result.is_err(), "expected error for package name {:?}", name);
+    }
+}
+
+#[test]
+fn dto01_go_type_overrides() {
+    let mut options = DtoOptions::default();
+    options.go.type_overrides.insert(
+        "Record.Price".to_string(),
+        GoType::new("decimal.Decimal").with_import("github.com/shopspring/decimal"),
+    );
+    options
+        .go
+        .type_overrides
+        .insert("Record.User.Age".to_string(), GoType::new("int32"));
+    assert_golden_with_options(
+        "dto01_basic",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_type_overrides.go",
+    );
+}
+
+#[test]
+fn dto01_go_json_number() {
+    let mut options = DtoOptions::default();
+    options.go.json_number = true;
+    assert_golden_with_options(
+        "dto01_basic",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_json_number.go",
+    );
+}
+
+#[test]
+fn dto01_go_omitzero() {
+    let mut options = DtoOptions::default();
+    options.go.go_version = Some("1.24".to_string());
+    assert_golden_with_options("dto01_basic", DtoLanguage::Go, &options, "expected_go_omitzero.go");
+}
+
+#[test]
+fn dto01_go_omitempty_before_1_24() {
+    let mut options = DtoOptions::default();
+    options.go.go_version = Some("1.23".to_string());
+    assert_golden_with_options("dto01_basic", DtoLanguage::Go, &options, "expected_go.go");
+
+    options.go.go_version = Some("1.24".to_string());
+    options.go.optional_strategy = OptionalStrategy::AlwaysPointer;
+    assert_golden_with_options(
+        "dto01_basic",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_always_pointer.go",
+    );
+}
+
+#[test]
+fn dto01_go_rejects_invalid_go_version() {
+    let rule = load_rule(&fixtures_dir().join("dto01_basic").join("rules.yaml"));
+    let mut options = DtoOptions::default();
+    options.go.go_version = Some("latest".to_string());
+    let err = generate_dto_with_options(&rule, DtoLanguage::Go, None, &options).unwrap_err();
+    assert_eq!(err.to_string(), "invalid Go version: latest");
+}
+
+#[test]
+fn dto01_go_raw_decoders() {
+    let mut options = DtoOptions::default();
+    options.go.emit_raw_decoders = true;
+    assert_golden_with_options(
+        "dto01_basic",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_raw_decoders.go",
+    );
+}
+
+#[test]
+fn dto01_go_getters() {
+    let mut options = DtoOptions::default();
+    options.go.emit_getters = true;
+    assert_golden_with_options("dto01_basic", DtoLanguage::Go, &options, "expected_go_getters.go");
+}
+
+#[test]
+fn dto01_go_source_hash_header() {
+    let mut options = DtoOptions::default();
+    options.go.header = GoHeader::WithSourceHash;
+    assert_golden_with_options(
+        "dto01_basic",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_source_hash.go",
+    );
+}
+
+#[test]
+fn dto01_go_do_not_edit_header() {
+    let rule = load_rule(&fixtures_dir().join("dto01_basic").join("rules.yaml"));
+    let mut options = DtoOptions::default();
+    options.go.header = GoHeader::DoNotEdit;
+    let output = generate_dto_with_options(&rule, DtoLanguage::Go, None, &options).unwrap();
+    assert!(
+        output.starts_with("// Code generated by transform-rules; DO NOT EDIT.\n\npackage dto\n")
+    );
+}
+
+#[test]
+fn go_source_hash_changes_with_schema() {
+    let mut options = DtoOptions::default();
+    options.go.header = GoHeader::WithSourceHash;
+    let hash_line = |case: &str| {
+        let rule = load_rule(&fixtures_dir().join(case).join("rules.yaml"));
+        let output = generate_dto_with_options(&rule, DtoLanguage::Go, None, &options).unwrap();
+        output.lines().nth(1).unwrap().to_string()
+    };
+    assert!(hash_line("dto01_basic").starts_with("// Source hash: fnv1a64:"));
+    assert_eq!(hash_line("dto01_basic"), hash_line("dto01_basic"));
+    assert_ne!(hash_line("dto01_basic"), hash_line("dto05_arrays"));
+}
+
+#[test]
+fn dto05_go_raw_decoders_without_raw_fields() {
+    let mut options = DtoOptions::default();
+    options.go.emit_raw_decoders = true;
+    assert_golden_with_options("dto05_arrays", DtoLanguage::Go, &options, "expected_go.go");
+}
+
+#[test]
+fn dto01_reports_fallback_warnings() {
+    let rule = load_rule(&fixtures_dir().join("dto01_basic").join("rules.yaml"));
+    let (output, warnings) =
+        generate_dto_with_warnings(&rule, DtoLanguage::Go, None, &DtoOptions::default())
+            .expect("dto failed");
+    assert_eq!(output, load_text(&fixtures_dir().join("dto01_basic").join("expected_go.go")));
+    let warnings: Vec<String> = warnings.iter().map(|warning| warning.to_string()).collect();
+    assert_eq!(
+        warnings,
+        vec![
+            "Record.user.name: type could not be inferred, fell back to json.RawMessage",
+            "Record.meta: type could not be inferred, fell back to json.RawMessage",
+            "Record.user-name: type could not be inferred, fell back to json.RawMessage",
+            "Record.class: type could not be inferred, fell back to json.RawMessage",
+        ]
+    );
+}
+
+#[test]
+fn dto01_go_unknown_type_any_drops_json_import() {
+    let mut options = DtoOptions::default();
+    options.go.unknown_type = GoType::new("any");
+    assert_golden_with_options(
+        "dto01_basic",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_unknown_any.go",
+    );
+}
+
+#[test]
+fn dto01_go_unknown_type_custom() {
+    let rule = load_rule(&fixtures_dir().join("dto01_basic").join("rules.yaml"));
+    let mut options = DtoOptions::default();
+    options.go.unknown_type =
+        GoType::new("jsontext.Value").with_import("github.com/go-json-experiment/json/jsontext");
+    let (output, warnings) =
+        generate_dto_with_warnings(&rule, DtoLanguage::Go, None, &options).expect("dto failed");
+    assert!(output.contains("import \"github.com/go-json-experiment/json/jsontext\"\n"));
+    assert!(output.contains("\tMeta     *jsontext.Value `json:\"meta,omitempty\"`\n"));
+    assert_eq!(
+        warnings[0].to_string(),
+        "Record.user.name: type could not be inferred, fell back to jsontext.Value"
+    );
+}
+
+#[test]
+fn dto01_strict_rejects_fallbacks() {
+    let rule = load_rule(&fixtures_dir().join("dto01_basic").join("rules.yaml"));
+    let options = DtoOptions {
+        strict: true,
+        ..DtoOptions::default()
+    };
+    let err = generate_dto_with_options(&rule, DtoLanguage::TypeScript, None, &options)
+        .unwrap_err();
+    assert_eq!(
+        err.to_string(),
+        "Record.user.name: type could not be inferred, fell back to unknown"
+    );
+}
+
+#[test]
+fn dto05_array_item_names_keep_singular_words() {
+    let mut yaml = String::from("version: 1\ninput:\n  format: json\nmappings:\n");
+    for target in ["status", "series", "alias", "entries", "addresses"] {
+        yaml.push_str(&format!(
+            "  - target: \"{}\"\n    source: \"{}\"\n    dto:\n      items:\n        \
+             fields:\n          - name: \"id\"\n            type: \"string\"\n",
+            target, target
+        ));
+    }
+    let rule = parse_rule_file(&yaml).unwrap();
+    let output = generate_dto(&rule, DtoLanguage::Go, None).expect("dto failed");
+    for name in [
+        "RecordStatusItem",
+        "RecordSeriesItem",
+        "RecordAliasItem",
+        "RecordEntry",
+        "RecordAddress",
+    ] {
+        assert!(output.contains(&format!("type {} struct", name)), "{}", output);
+    }
+}
+
+#[test]
+fn dto05_strict_accepts_typed_rules() {
+    let rule = load_rule(&fixtures_dir().join("dto05_arrays").join("rules.yaml"));
+    let options = DtoOptions {
+        strict: true,
+        ..DtoOptions::default()
+    };
+    let (_, warnings) =
+        generate_dto_with_warnings(&rule, DtoLanguage::Go, None, &options).expect("dto failed");
+    assert!(warnings.is_empty());
+}
+
+#[test]
+fn dto01_go_alphabetical_field_order() {
+    let options = DtoOptions {
+        field_order: FieldOrder::Alphabetical,
+        ..DtoOptions::default()
+    };
+    assert_golden_with_options(
+        "dto01_basic",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_alphabetical.go",
+    );
+}
+
+#[test]
+fn dto01_output_is_deterministic() {
+    let rule = load_rule(&fixtures_dir().join("dto01_basic").join("rules.yaml"));
+    let langs = [
+        DtoLanguage::Rust,
+        DtoLanguage::TypeScript,
+        DtoLanguage::Python,
+        DtoLanguage::Pydantic,
+        DtoLanguage::Go,
+        DtoLanguage::Java,
+        DtoLanguage::Kotlin,
+        DtoLanguage::Swift,
+    ];
+    for field_order in [FieldOrder::Declaration, FieldOrder::Alphabetical] {
+        let options = DtoOptions {
+            field_order,
+            ..DtoOptions::default()
+        };
+        for lang in langs {
+            let first =
+                generate_dto_with_options(&rule, lang, None, &options).expect("dto failed");
+            let second =
+                generate_dto_with_options(&rule, lang, None, &options).expect("dto failed");
+            assert_eq!(first.as_bytes(), second.as_bytes(), "{:?}", lang);
+        }
+    }
+}
+
+#[test]
+fn dto05_arrays_rust() {
+    assert_golden_case("dto05_arrays", DtoLanguage::Rust, "expected_rust.rs");
+}
+
+#[test]
+fn dto05_arrays_typescript() {
+    assert_golden_case("dto05_arrays", DtoLanguage::TypeScript, "expected_typescript.ts");
+}
+
+#[test]
+fn dto05_arrays_python() {
+    assert_golden_case("dto05_arrays", DtoLanguage::Python, "expected_python.py");
+}
+
+#[test]
+fn dto05_arrays_go() {
+    assert_golden_case("dto05_arrays", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto05_arrays_java() {
+    assert_golden_case("dto05_arrays", DtoLanguage::Java, "expected_java.java");
+}
+
+#[test]
+fn dto05_arrays_kotlin() {
+    assert_golden_case("dto05_arrays", DtoLanguage::Kotlin, "expected_kotlin.kt");
+}
+
+#[test]
+fn dto05_arrays_swift() {
+    assert_golden_case("dto05_arrays", DtoLanguage::Swift, "expected_swift.swift");
+}
+
+#[test]
+fn dto05_arrays_protobuf() {
+    assert_golden_case("dto05_arrays", DtoLanguage::Protobuf, "expected_protobuf.proto");
+}
+
+#[test]
+fn dto06_map_basic_rust() {
+    assert_golden_case("dto06_map_basic", DtoLanguage::Rust, "expected_rust.rs");
+}
+
+#[test]
+fn dto06_map_basic_typescript() {
+    assert_golden_case("dto06_map_basic", DtoLanguage::TypeScript, "expected_typescript.ts");
+}
+
+#[test]
+fn dto06_map_basic_python() {
+    assert_golden_case("dto06_map_basic", DtoLanguage::Python, "expected_python.py");
+}
+
+#[test]
+fn dto06_map_basic_pydantic() {
+    assert_golden_case("dto06_map_basic", DtoLanguage::Pydantic, "expected_pydantic.py");
+}
+
+#[test]
+fn dto06_map_basic_go() {
+    assert_golden_case("dto06_map_basic", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto06_map_basic_java() {
+    assert_golden_case("dto06_map_basic", DtoLanguage::Java, "expected_java.java");
+}
+
+#[test]
+fn dto06_map_basic_kotlin() {
+    assert_golden_case("dto06_map_basic", DtoLanguage::Kotlin, "expected_kotlin.kt");
+}
+
+#[test]
+fn dto06_map_basic_swift() {
+    assert_golden_case("dto06_map_basic", DtoLanguage::Swift, "expected_swift.swift");
+}
+
+#[test]
+fn dto09_openapi_rust() {
+    assert_openapi_golden("dto09_openapi_basic", DtoLanguage::Rust, "expected_rust.rs");
+}
+
+#[test]
+fn dto09_openapi_typescript() {
+    assert_openapi_golden(
+        "dto09_openapi_basic",
+        DtoLanguage::TypeScript,
+        "expected_typescript.ts",
+    );
+}
+
+#[test]
+fn dto09_openapi_python() {
+    assert_openapi_golden("dto09_openapi_basic", DtoLanguage::Python, "expected_python.py");
+}
+
+#[test]
+fn dto09_openapi_go() {
+    assert_openapi_golden("dto09_openapi_basic", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto09_openapi_go_getters() {
+    let mut options = DtoOptions::default();
+    options.go.emit_getters = true;
+    assert_openapi_golden_with_options(
+        "dto09_openapi_basic",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_getters.go",
+    );
+}
+
+#[test]
+fn dto09_openapi_java() {
+    assert_openapi_golden("dto09_openapi_basic", DtoLanguage::Java, "expected_java.java");
+}
+
+#[test]
+fn dto09_openapi_kotlin() {
+    assert_openapi_golden("dto09_openapi_basic", DtoLanguage::Kotlin, "expected_kotlin.kt");
+}
+
+#[test]
+fn dto09_openapi_swift() {
+    assert_openapi_golden("dto09_openapi_basic", DtoLanguage::Swift, "expected_swift.swift");
+}
+
+#[test]
+fn dto09_openapi_protobuf() {
+    assert_openapi_golden("dto09_openapi_basic", DtoLanguage::Protobuf, "expected_protobuf.proto");
+}
+
+#[test]
+fn dto09_openapi_rejects_unknown_ref() {
+    let source = r##"
+openapi: "3.0.3"
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        address:
+          $ref: "#/components/schemas/Missing"
+"##;
+    let err = generate_dto_from_openapi(source, DtoLanguage::Go, &DtoOptions::default())
+        .unwrap_err();
+    assert!(err.to_string().contains("Missing"));
+}
+
+#[test]
+fn dto10_openapi_recursive_rust() {
+    assert_openapi_golden("dto10_openapi_recursive", DtoLanguage::Rust, "expected_rust.rs");
+}
+
+#[test]
+fn dto10_openapi_recursive_typescript() {
+    assert_openapi_golden(
+        "dto10_openapi_recursive",
+        DtoLanguage::TypeScript,
+        "expected_typescript.ts",
+    );
+}
+
+#[test]
+fn dto10_openapi_recursive_python() {
+    assert_openapi_golden("dto10_openapi_recursive", DtoLanguage::Python, "expected_python.py");
+}
+
+#[test]
+fn dto10_openapi_recursive_pydantic() {
+    assert_openapi_golden(
+        "dto10_openapi_recursive",
+        DtoLanguage::Pydantic,
+        "expected_pydantic.py",
+    );
+}
+
+#[test]
+fn dto10_openapi_recursive_go() {
+    assert_openapi_golden("dto10_openapi_recursive", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto10_openapi_recursive_java() {
+    assert_openapi_golden("dto10_openapi_recursive", DtoLanguage::Java, "expected_java.java");
+}
+
+#[test]
+fn dto10_openapi_recursive_kotlin() {
+    assert_openapi_golden("dto10_openapi_recursive", DtoLanguage::Kotlin, "expected_kotlin.kt");
+}
+
+#[test]
+fn dto10_openapi_recursive_swift() {
+    assert_openapi_golden("dto10_openapi_recursive", DtoLanguage::Swift, "expected_swift.swift");
+}
+
+#[test]
+fn dto11_openapi_one_of_go_unions_disabled() {
+    assert_openapi_golden("dto11_openapi_one_of", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto11_openapi_one_of_go_unions() {
+    let mut options = DtoOptions::default();
+    options.go.emit_unions = true;
+    assert_openapi_golden_with_options(
+        "dto11_openapi_one_of",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_unions.go",
+    );
+}
+
+#[test]
+fn dto11_openapi_one_of_go_union_unmarshal() {
+    let mut options = DtoOptions::default();
+    options.go.emit_unions = true;
+    options.go.emit_union_unmarshal = true;
+    assert_openapi_golden_with_options(
+        "dto11_openapi_one_of",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_union_unmarshal.go",
+    );
+}
+
+#[test]
+fn dto11_openapi_one_of_go_unions_round_trip() {
+    let mut options = DtoOptions::default();
+    options.go.package_name = "main".to_string();
+    options.go.emit_unions = true;
+    options.go.emit_union_unmarshal = true;
+    let source = generate_dto_from_openapi(
+        &load_text(&fixtures_dir().join("dto11_openapi_one_of").join("openapi.yaml")),
+        DtoLanguage::Go,
+        &options,
+    )
+    .expect("dto failed");
+
+    let input = concat!(
+        r#"{"name":"Ann","pet":{"kind":"cat","lives":9},"#,
+        r#""favorite":{"kind":"dog","good":true}}"#
+    );
+    let Some(actual) = go_round_trip(&source, "Owner", input) else {
+        return;
+    };
+    let expected: serde_json::Value = serde_json::from_str(input).expect("parse input");
+    assert_eq!(actual, expected);
+
+    let unknown = go_round_trip(&source, "Pet", r#"{"kind":"lizard"}"#).expect("go run");
+    assert_eq!(unknown, serde_json::Value::Null);
+}
+
+#[test]
+fn dto11_openapi_one_of_typescript() {
+    assert_openapi_golden(
+        "dto11_openapi_one_of",
+        DtoLanguage::TypeScript,
+        "expected_typescript.ts",
+    );
+}
+
+#[test]
+fn dto11_openapi_one_of_json_schema() {
+    assert_openapi_golden(
+        "dto11_openapi_one_of",
+        DtoLanguage::JsonSchema,
+        "expected_json_schema.json",
+    );
+}
+
+#[test]
+fn dto11_openapi_one_of_warnings() {
+    let source = load_text(&fixtures_dir().join("dto11_openapi_one_of").join("openapi.yaml"));
+    let (_, warnings) = generate_dto_from_openapi_with_warnings(
+        &source,
+        DtoLanguage::Go,
+        &DtoOptions::default(),
+    )
+    .expect("dto failed");
+    let expected = "oneOf union is not supported, fell back to json.RawMessage";
+    assert!(!warnings.is_empty());
+    assert!(warnings.iter().all(|warning| warning.message == expected));
+
+    let mut options = DtoOptions::default();
+    options.go.emit_unions = true;
+    let (_, warnings) =
+        generate_dto_from_openapi_with_warnings(&source, DtoLanguage::Go, &options)
+            .expect("dto failed");
+    assert!(warnings.is_empty());
+}
+
+#[test]
+fn dto12_go_int_widths() {
+    assert_golden_case("dto12_go_int_widths", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto12_go_json_number() {
+    let mut options = DtoOptions::default();
+    options.go.json_number = true;
+    assert_golden_with_options(
+        "dto12_go_int_widths",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_json_number.go",
+    );
+}
+
+#[test]
+fn dto13_json_schema_refs_go() {
+    assert_json_schema_golden("dto13_json_schema_refs", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto13_json_schema_refs_typescript() {
+    assert_json_schema_golden(
+        "dto13_json_schema_refs",
+        DtoLanguage::TypeScript,
+        "expected_typescript.ts",
+    );
+}
+
+#[test]
+fn dto14_json_schema_rejects_circular_refs() {
+    let path = fixtures_dir()
+        .join("dto14_json_schema_circular")
+        .join("schema.json");
+    let err = generate_dto_from_json_schema(&path, DtoLanguage::Go, None, &DtoOptions::default())
+        .unwrap_err();
+    assert_eq!(err.to_string(), "Record.code: circular $ref: Code");
+}
+
+#[test]
+fn dto14_json_schema_rejects_missing_file() {
+    let path = fixtures_dir()
+        .join("dto14_json_schema_circular")
+        .join("missing.json");
+    let err = generate_dto_from_json_schema(&path, DtoLanguage::Go, None, &DtoOptions::default())
+        .unwrap_err();
+    assert!(err.to_string().contains("missing.json"));
+}
+
+#[test]
+fn dto15_go_nested_types() {
+    assert_golden_case("dto15_go_inline_nested", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto15_go_inline_anonymous() {
+    let mut options = DtoOptions::default();
+    options.go.inline_anonymous = true;
+    assert_golden_with_options(
+        "dto15_go_inline_nested",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_inline.go",
+    );
+}
+
+#[test]
+fn dto15_go_inline_anonymous_indent_spaces() {
+    let mut options = DtoOptions::default();
+    options.go.inline_anonymous = true;
+    options.go.indent = GoIndent::Spaces(4);
+    let base = fixtures_dir().join("dto15_go_inline_nested");
+    let rule = load_rule(&base.join("rules.yaml"));
+    let output = generate_dto_with_options(&rule, DtoLanguage::Go, None, &options)
+        .expect("dto failed");
+    let expected: Vec<String> = load_text(&base.join("expected_go_inline.go"))
+        .lines()
+        .map(|line| {
+            let content = line.trim_start_matches('\t');
+            format!("{}{}", "    ".repeat(line.len() - content.len()), content)
+        })
+        .collect();
+    assert_eq!(output, expected.join("\n"));
+    assert!(output.contains("\n            Geo  *struct {\n                Lat *float64"));
+}
+
+#[test]
+fn dto16_defaults_go() {
+    assert_openapi_golden("dto16_defaults", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto16_defaults_go_constructors() {
+    let mut options = DtoOptions::default();
+    options.go.emit_enums = true;
+    options.go.emit_constructors = true;
+    assert_openapi_golden_with_options(
+        "dto16_defaults",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_constructors.go",
+    );
+}
+
+#[test]
+fn dto16_defaults_pydantic() {
+    assert_openapi_golden("dto16_defaults", DtoLanguage::Pydantic, "expected_pydantic.py");
+}
+
+#[test]
+fn dto17_additional_properties_ignored() {
+    assert_openapi_golden("dto17_additional_properties", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto17_additional_properties_capture_raw() {
+    let mut options = DtoOptions::default();
+    options.go.additional_properties = AdditionalPropertiesPolicy::CaptureRaw;
+    assert_openapi_golden_with_options(
+        "dto17_additional_properties",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_capture_raw.go",
+    );
+}
+
+#[test]
+fn dto17_additional_properties_error() {
+    let source = load_text(
+        &fixtures_dir()
+            .join("dto17_additional_properties")
+            .join("openapi.yaml"),
+    );
+    let mut options = DtoOptions::default();
+    options.go.additional_properties = AdditionalPropertiesPolicy::Error;
+    let err = generate_dto_from_openapi(&source, DtoLanguage::Go, &options).unwrap_err();
+    assert_eq!(err.to_string(), "Customer: additionalProperties is not supported");
+}
+
+fn go_round_trip(source: &str, type_name: &str, input: &str) -> Option<serde_json::Value> {
+    let dir = std::env::temp_dir().join(format!(
+        "go_round_trip_{}_{}",
+        type_name,
+        std::process::id()
+    ));
+    fs::create_dir_all(&dir).expect("create temp dir");
+    fs::write(dir.join("go.mod"), "module roundtrip\n\ngo 1.21\n").expect("write go.mod");
+    fs::write(dir.join("dto.go"), format!("{}\n", source)).expect("write dto.go");
+    fs::write(
+        dir.join("main.go"),
+        format!(
+            "package main\n\nimport (\n\t\"encoding/json\"\n\t\"io\"\n\t\"os\"\n)\n\n\
+             func main() {{\n\tdata, _ := io.ReadAll(os.Stdin)\n\tvar value {}\n\
+             \tif err := json.Unmarshal(data, &value); err != nil {{\n\t\tpanic(err)\n\t}}\n\
+             \tout, err := json.Marshal(value)\n\tif err != nil {{\n\t\tpanic(err)\n\t}}\n\
+             \tos.Stdout.Write(out)\n}}\n",
+            type_name
+        ),
+    )
+    .expect("write main.go");
+
+    let mut child = match Command::new("go")
+        .arg("run")
+        .arg(".")
+        .current_dir(&dir)
+        .stdin(Stdio::piped())
+        .stdout(Stdio::piped())
+        .spawn()
+    {
+        Ok(child) => child,
+        Err(err) if err.kind() == ErrorKind::NotFound => return None,
+        Err(err) => panic!("failed to run go: {}", err),
+    };
+    child
+        .stdin
+        .take()
+        .expect("go stdin")
+        .write_all(input.as_bytes())
+        .expect("write go stdin");
+    let output = child.wait_with_output().expect("go output");
+    let _ = fs::remove_dir_all(&dir);
+    assert!(output.status.success(), "go run failed");
+    Some(serde_json::from_slice(&output.stdout).expect("parse output"))
+}
+
+#[test]
+fn dto17_additional_properties_round_trip() {
+    let base = fixtures_dir().join("dto17_additional_properties");
+    let mut options = DtoOptions::default();
+    options.go.package_name = "main".to_string();
+    options.go.additional_properties = AdditionalPropertiesPolicy::CaptureRaw;
+    let source = generate_dto_from_openapi(
+        &load_text(&base.join("openapi.yaml")),
+        DtoLanguage::Go,
+        &options,
+    )
+    .expect("dto failed");
+
+    let input = load_text(&base.join("roundtrip.json"));
+    let Some(actual) = go_round_trip(&source, "Order", &input) else {
+        return;
+    };
+    let expected: serde_json::Value = serde_json::from_str(&input).expect("parse input");
+    assert_eq!(actual, expected);
+}
+
+#[test]
+fn dto31_go_all_of_capture_raw() {
+    let mut options = DtoOptions::default();
+    options.go.additional_properties = AdditionalPropertiesPolicy::CaptureRaw;
+    assert_openapi_golden_with_options(
+        "dto31_go_all_of_capture_raw",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_capture_raw.go",
+    );
+}
+
+#[test]
+fn dto31_go_all_of_capture_raw_round_trip() {
+    let base = fixtures_dir().join("dto31_go_all_of_capture_raw");
+    let mut options = DtoOptions::default();
+    options.go.package_name = "main".to_string();
+    options.go.additional_properties = AdditionalPropertiesPolicy::CaptureRaw;
+    let source = generate_dto_from_openapi(
+        &load_text(&base.join("openapi.yaml")),
+        DtoLanguage::Go,
+        &options,
+    )
+    .expect("dto failed");
+
+    let input = load_text(&base.join("roundtrip.json"));
+    let Some(actual) = go_round_trip(&source, "Record", &input) else {
+        return;
+    };
+    let expected: serde_json::Value = serde_json::from_str(&input).expect("parse input");
+    assert_eq!(actual, expected);
+}
+
+#[test]
+fn dto31_go_capture_raw_tag_naming_round_trip() {
+    let base = fixtures_dir().join("dto31_go_all_of_capture_raw");
+    let mut options = DtoOptions::default();
+    options.go.package_name = "main".to_string();
+    options.go.additional_properties = AdditionalPropertiesPolicy::CaptureRaw;
+    options.go.tag_naming = TagNamingStrategy::CamelCase;
+    let source = generate_dto_from_openapi(
+        &load_text(&base.join("openapi.yaml")),
+        DtoLanguage::Go,
+        &options,
+    )
+    .expect("dto failed");
+    assert!(source.contains(r#"[]string{"createdAt", "id", "name"}"#));
+
+    let input = r#"{"id":"1","createdAt":"2024-01-02T03:04:05Z","name":"n","note":"kept"}"#;
+    let Some(actual) = go_round_trip(&source, "Record", input) else {
+        return;
+    };
+    let expected: serde_json::Value = serde_json::from_str(input).expect("parse input");
+    assert_eq!(actual, expected);
+}
+
+#[test]
+fn dto32_pydantic_escapes_quoted_keys() {
+    assert_openapi_golden("dto32_quoted_keys", DtoLanguage::Pydantic, "expected_pydantic.py");
+}
+
+#[test]
+fn dto32_python_escapes_quoted_keys() {
+    assert_openapi_golden("dto32_quoted_keys", DtoLanguage::Python, "expected_python.py");
+}
+
+#[test]
+fn dto18_go_identifier_collisions() {
+    assert_golden_case("dto18_identifier_collisions", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto18_rust_identifier_collisions() {
+    assert_golden_case("dto18_identifier_collisions", DtoLanguage::Rust, "expected_rust.rs");
+}
+
+#[test]
+fn dto19_batch_per_file() {
+    let base = fixtures_dir().join("dto19_batch");
+    let batch = generate_dto_batch(
+        &base.join("inputs"),
+        DtoLanguage::Go,
+        &DtoOptions::default(),
+        BatchMode::PerFile,
+    )
+    .expect("batch failed");
+
+    let names: Vec<&str> = batch.outputs.iter().map(|output| output.file_name.as_str()).collect();
+    assert_eq!(names, ["customers.go", "orders.go"]);
+    for output in &batch.outputs {
+        let expected = load_text(&base.join(format!("expected_go_{}", output.file_name)));
+        assert_eq!(output.code, expected);
+    }
+    assert_eq!(batch.failures.len(), 1);
+    assert!(batch.failures[0].source.ends_with("broken.yaml"));
+    assert!(batch.failures[0].error.to_string().starts_with("invalid YAML: "));
+}
+
+#[test]
+fn dto19_batch_merged_dedups_shared_types() {
+    let base = fixtures_dir().join("dto19_batch");
+    let batch = generate_dto_batch(
+        &base.join("inputs"),
+        DtoLanguage::Go,
+        &DtoOptions::default(),
+        BatchMode::Merged,
+    )
+    .expect("batch failed");
+
+    assert_eq!(batch.outputs.len(), 1);
+    assert_eq!(batch.outputs[0].file_name, "dto.go");
+    assert_eq!(batch.outputs[0].sources.len(), 2);
+    assert_eq!(batch.outputs[0].code, load_text(&base.join("expected_go_merged.go")));
+    assert_eq!(batch.failures.len(), 1);
+}
+
+#[test]
+fn dto19_batch_strict_stops_on_error() {
+    let options = DtoOptions {
+        strict: true,
+        ..DtoOptions::default()
+    };
+    let dir = fixtures_dir().join("dto19_batch").join("inputs");
+    let err = generate_dto_batch(&dir, DtoLanguage::Go, &options, BatchMode::PerFile).unwrap_err();
+    assert!(err.to_string().contains("broken.yaml: invalid YAML: "));
+}
+
+#[test]
+fn batch_parallel_output_matches_serial() {
+    let summary = |batch: transform_rules::DtoBatch| {
+        let outputs: Vec<(String, Vec<PathBuf>, String)> = batch
+            .outputs
+            .into_iter()
+            .map(|output| (output.file_name, output.sources, output.code))
+            .collect();
+        let failures: Vec<(PathBuf, String)> = batch
+            .failures
+            .into_iter()
+            .map(|failure| (failure.source, failure.error.to_string()))
+            .collect();
+        (outputs, failures)
+    };
+    for case in fs::read_dir(fixtures_dir()).expect("read fixtures") {
+        let dir = case.expect("fixture case").path();
+        for mode in [BatchMode::PerFile, BatchMode::Merged] {
+            let options = DtoOptions::default();
+            let serial = generate_dto_batch_with_jobs(&dir, DtoLanguage::Go, &options, mode, 1)
+                .expect("serial batch failed");
+            let parallel = generate_dto_batch_with_jobs(&dir, DtoLanguage::Go, &options, mode, 4)
+                .expect("parallel batch failed");
+            assert_eq!(summary(serial), summary(parallel), "{}", dir.display());
+        }
+    }
+}
+
+#[test]
+fn dto20_go_nullable_collapses_into_optional_by_default() {
+    assert_openapi_golden("dto20_nullable_optional", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto20_go_pointer_for_nullable() {
+    let mut options = DtoOptions::default();
+    options.go.optional_strategy = OptionalStrategy::PointerForNullable;
+    assert_openapi_golden_with_options(
+        "dto20_nullable_optional",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_pointer_for_nullable.go",
+    );
+}
+
+#[test]
+fn dto21_go_groups_and_aliases_imports() {
+    let mut options = DtoOptions::default();
+    options.go.format_types.insert(
+        "uuid".to_string(),
+        GoType::new("uuid.UUID").with_import("github.com/google/uuid"),
+    );
+    let decimal = GoType::new("dec.Decimal")
+        .with_import("github.com/shopspring/decimal")
+        .with_import_alias("dec");
+    options.go.type_overrides.insert("Record.Price".to_string(), decimal.clone());
+    options.go.type_overrides.insert("Record.Discount".to_string(), decimal);
+    assert_golden_with_options("dto21_go_imports", DtoLanguage::Go, &options, "expected_go.go");
+}
+
+#[test]
+fn dto22_go_always_emit_keeps_value_without_omitempty() {
+    assert_golden_case("dto22_go_always_emit", DtoLanguage::Go, "expected_go.go");
+
+    let mut options = DtoOptions::default();
+    options.go.optional_strategy = OptionalStrategy::AlwaysPointer;
+    let rule = load_rule(&fixtures_dir().join("dto22_go_always_emit").join("rules.yaml"));
+    let output = generate_dto_with_options(&rule, DtoLanguage::Go, None, &options)
+        .expect("dto failed");
+    assert!(output.contains("\tActive   bool     `json:\"active\"`\n"));
+    assert!(output.contains("\tVerified *bool    `json:\"verified,omitempty\"`\n"));
+}
+
+#[test]
+fn dto24_openapi_all_of_go_embeds_base_types() {
+    assert_openapi_golden("dto24_openapi_all_of", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto24_openapi_all_of_typescript_flattens_base_fields() {
+    assert_openapi_golden(
+        "dto24_openapi_all_of",
+        DtoLanguage::TypeScript,
+        "expected_typescript.ts",
+    );
+}
+
+#[test]
+fn dto25_go_bytes_formats_map_to_byte_slices() {
+    assert_openapi_golden("dto25_go_bytes", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto25_go_bytes_keep_omitempty_on_go_1_24() {
+    let mut options = DtoOptions::default();
+    options.go.go_version = Some("1.24".to_string());
+    assert_openapi_golden_with_options(
+        "dto25_go_bytes",
+        DtoLanguage::Go,
+        &options,
+        "expected_go.go",
+    );
+}
+
+#[test]
+fn dto26_go_template_renders_house_style() {
+    let base = fixtures_dir().join("dto26_go_template");
+    let mut options = DtoOptions::default();
+    options.go.template = Some(load_text(&base.join("template.gotmpl")));
+    options.go.emit_getters = true;
+    assert_openapi_golden_with_options(
+        "dto26_go_template",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_template.go",
+    );
+}
+
+#[test]
+fn dto26_go_template_exposes_generation_time() {
+    let source = load_text(&fixtures_dir().join("dto26_go_template").join("openapi.yaml"));
+    let mut options = DtoOptions::default();
+    let template = "// Generated at {{generated_at}}\npackage {{package}}\n";
+    options.go.template = Some(template.to_string());
+    let output = generate_dto_from_openapi(&source, DtoLanguage::Go, &options).expect("dto");
+    let (first, rest) = output.split_once('\n').expect("two lines");
+    let timestamp = first.strip_prefix("// Generated at ").expect("header line");
+    assert!(chrono::DateTime::parse_from_rfc3339(timestamp).is_ok(), "{}", timestamp);
+    assert!(timestamp.ends_with('Z'));
+    assert_eq!(rest, "package dto");
+}
+
+#[test]
+fn dto26_go_template_reports_invalid_templates() {
+    let source = load_text(&fixtures_dir().join("dto26_go_template").join("openapi.yaml"));
+    let cases = [
+        ("{{#each structs}}{{nam}}{{/each}}", "invalid template: unknown variable: nam"),
+        ("{{#each structs}}{{name}}", "invalid template: unclosed {{#each}}"),
+        ("{{#if package}}{{/each}}", "invalid template: unexpected {{/each}}"),
+        ("{{#with structs}}{{/with}}", "invalid template: unknown helper: with"),
+        ("{{structs}}", "invalid template: structs is not a scalar value"),
+    ];
+    for (template, expected) in cases {
+        let mut options = DtoOptions::default();
+        options.go.template = Some(template.to_string());
+        let err = generate_dto_from_openapi(&source, DtoLanguage::Go, &options).unwrap_err();
+        assert_eq!(err.to_string(), expected, "template {:?}", template);
+    }
+}
+
+#[test]
+fn dto27_openapi_tuples_fall_back_by_default() {
+    let source = load_text(&fixtures_dir().join("dto27_openapi_tuples").join("openapi.yaml"));
+    let (output, warnings) =
+        generate_dto_from_openapi_with_warnings(&source, DtoLanguage::Go, &DtoOptions::default())
+            .expect("dto failed");
+    let expected = load_text(
+        &fixtures_dir()
+            .join("dto27_openapi_tuples")
+            .join("expected_go.go"),
+    );
+    assert_eq!(output, expected);
+    let paths: Vec<&str> = warnings.iter().map(|warning| warning.path.as_str()).collect();
+    assert_eq!(paths, vec!["Series.samples", "Series.range"]);
+    assert!(warnings
+        .iter()
+        .all(|warning| warning.message == "tuple is not supported, fell back to json.RawMessage"));
+}
+
+#[test]
+fn dto27_openapi_tuples_go_structs() {
+    let mut options = DtoOptions::default();
+    options.go.emit_tuples = true;
+    assert_openapi_golden_with_options(
+        "dto27_openapi_tuples",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_tuples.go",
+    );
+}
+
+#[test]
+fn dto27_openapi_tuples_go_marshal() {
+    let mut options = DtoOptions::default();
+    options.go.emit_tuples = true;
+    options.go.emit_tuple_marshal = true;
+    assert_openapi_golden_with_options(
+        "dto27_openapi_tuples",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_tuple_marshal.go",
+    );
+}
+
+#[test]
+fn dto27_openapi_tuples_reject_unsupported_shapes() {
+    let cases = [
+        (
+            "prefixItems: [{type: string}]\n          items: {type: number}",
+            "Series.pair: tuple with additional items is not supported (set items: false)",
+        ),
+        (
+            "items: [{type: string}]\n          additionalItems: true",
+            "Series.pair: tuple with additional items is not supported (set items: false)",
+        ),
+        (
+            "prefixItems: [{type: string}]",
+            "Series.pair: tuple with additional items is not supported (set items: false)",
+        ),
+        (
+            "items: [{type: string}]",
+            "Series.pair: tuple with additional items is not supported (set items: false)",
+        ),
+        (
+            "prefixItems: []\n          items: false",
+            "Series.pair: tuple must have between 1 and 16 items",
+        ),
+        (
+            "prefixItems: [{type: object, properties: {a: {type: string}}}]\n          \
+             items: false",
+            "Series.pair: tuple items must not be inline objects; use $ref",
+        ),
+    ];
+    for (tuple, expected) in cases {
+        let source = format!(
+            "openapi: 3.1.0\ncomponents:\n  schemas:\n    Series:\n      type: object\n      \
+             properties:\n        pair:\n          type: array\n          {}\n",
+            tuple
+        );
+        let err = generate_dto_from_openapi(&source, DtoLanguage::Go, &DtoOptions::default())
+            .unwrap_err();
+        assert_eq!(err.to_string(), expected, "tuple {:?}", tuple);
+    }
+}
+
+#[test]
+fn dto28_identical_nested_types_are_kept_by_default() {
+    assert_golden_case("dto28_merge_identical_types", DtoLanguage::Go, "expected_go.go");
+}
+
+#[test]
+fn dto28_go_merges_identical_nested_types() {
+    let options = DtoOptions {
+        merge_identical_types: true,
+        ..DtoOptions::default()
+    };
+    assert_golden_with_options(
+        "dto28_merge_identical_types",
+        DtoLanguage::Go,
+        &options,
+        "expected_go_merged.go",
+    );
+}
+
+#[test]
+fn dto28_typescript_merges_identical_nested_types() {
+    let options = DtoOptions {
+        merge_identical_types: true,
+        ..DtoOptions::default()
+    };
+    assert_golden_with_options(
+        "dto28_merge_identical_types",
+        DtoLanguage::TypeScript,
+        &options,
+        "expected_typescript_merged.ts",
+    );
+}
+
+#[test]
+fn dto28_merge_keeps_components_and_merges_inline_objects() {
+    let owner = "type: object\n          properties:\n            name: {type: string}";
+    let source = format!(
+        "openapi: 3.1.0\ncomponents:\n  schemas:\n    Pet:\n      type: object\n      \
+         properties:\n        owner:\n          {}\n    Store:\n      type: object\n      \
+         properties:\n        manager:\n          {}\n",
+        owner, owner
+    );
+    let options = DtoOptions {
+        merge_identical_types: true,
+        ..DtoOptions::default()
+    };
+    let output = generate_dto_from_openapi(&source, DtoLanguage::Go, &options).unwrap();
+    assert!(output.contains("type Pet struct {\n\tOwner *PetOwner `json:\"owner,omitempty\"`\n}"));
+    assert!(output.contains("\tManager *PetOwner `json:\"manager,omitempty\"`\n"));
+    assert!(!output.contains("StoreManager"));
+}
+
+#[test]
+fn dto01_schema_round_trips_through_json() {
+    let base = fixtures_dir().join("dto01_basic");
+    let rule = load_rule(&base.join("rules.yaml"));
+    let schema = build_dto_schema(&rule, None).expect("schema failed");
+    let json = serde_json::to_string_pretty(&schema).expect("serialize failed");
+    let restored: DtoSchema = serde_json::from_str(&json).expect("deserialize failed");
+    assert_eq!(restored, schema);
+
+    for (lang, expected) in [
+        (DtoLanguage::Go, "expected_go.go"),
+        (DtoLanguage::Rust, "expected_rust.rs"),
+        (DtoLanguage::TypeScript, "expected_typescript.ts"),
+        (DtoLanguage::Pydantic, "expected_pydantic.py"),
+    ] {
+        let output = generate_dto_from_schema(&restored, lang, &DtoOptions::default())
+            .expect("dto failed");
+        assert_eq!(output, load_text(&base.join(expected)));
+    }
+}
+
+#[test]
+fn dto23_validate_schema_reports_every_problem() {
+    let base = fixtures_dir().join("dto23_schema_validation");
+    let schema: DtoSchema =
+        serde_json::from_str(&load_text(&base.join("ir.json"))).expect("invalid ir");
+    let errors: Vec<String> = validate_dto_schema(&schema)
+        .iter()
+        .map(|err| err.to_string())
+        .collect();
+    assert_eq!(
+        errors,
+        vec![
+            "Order.id: duplicate property",
+            "Order.customer: reference to undefined type Customer",
+            "Order.status: enum has no values",
+            "Order.items: reference to undefined type Item2",
+            "Item.meta: object has no properties",
+        ]
+    );
+
+    let err = generate_dto_from_schema(&schema, DtoLanguage::Go, &DtoOptions::default())
+        .expect_err("expected validation error");
+    assert_eq!(err.to_string(), "Order.id: duplicate property");
+}
+
+#[test]
+fn go_goldens_are_gofmt_fixed_points() {
+    let mut goldens = Vec::new();
+    for case in fs::read_dir(fixtures_dir()).expect("read fixtures") {
+        for entry in fs::read_dir(case.expect("fixture case").path()).expect("read case") {
+            let path = entry.expect("fixture file").path();
+            let name = path.file_name().and_then(|name| name.to_str()).unwrap_or("");
+            // Template output keeps the template's own spacing, so it is not gofmt-clean.
+            if name == "expected_go_template.go" {
+                continue;
+            }
+            if name.starts_with("expected_go") && name.ends_with(".go") {
+                goldens.push(path);
+            }
+        }
+    }
+    assert!(!goldens.is_empty());
+
+    for path in goldens {
+        let source = fs::read_to_string(&path).expect("read golden");
+        let mut child = match Command::new("gofmt")
+            .stdin(Stdio::piped())
+            .stdout(Stdio::piped())
+            .spawn()
+        {
+            Ok(child) => child,
+            Err(err) if err.kind() == ErrorKind::NotFound => return,
+            Err(err) => panic!("failed to run gofmt: {}", err),
+        };
+        child
+            .stdin
+            .take()
+            .expect("gofmt stdin")
+            .write_all(source.as_bytes())
+            .expect("write gofmt stdin");
+        let output = child.wait_with_output().expect("gofmt output");
+        assert!(output.status.success(), "gofmt rejected {}", path.display());
+        assert_eq!(
+            String::from_utf8_lossy(&output.stdout),
+            source,
+            "{} is not gofmt-clean",
+            path.display()
+        );
+    }
+}
+
+#[test]
+fn diff_generated_file_accepts_up_to_date_output() {
+    let base = fixtures_dir().join("dto01_basic");
+    let rule = load_rule(&base.join("rules.yaml"));
+    let output = generate_dto(&rule, DtoLanguage::Go, None).expect("dto failed");
+    let diff = diff_generated_file(&base.join("expected_go.go"), &format!("{}\n", output))
+        .expect("diff failed");
+    assert_eq!(diff, None);
+}
+
+#[test]
+fn unified_diff_reports_changed_lines() {
+    let old = "package dto\n\ntype Record struct {\n\tID\tstring\n\tName\tstring\n}\n";
+    let new = "package dto\n\ntype Record struct {\n\tID\tstring\n\tAge\tint64\n}\n";
+    let diff = unified_diff(old, new, "record.go", "record.go (generated)").expect("diff");
+    assert_eq!(
+        diff,
+        "--- record.go\n+++ record.go (generated)\n@@ -2,5 +2,5 @@\n \n type Record struct {\n \
+         \tID\tstring\n-\tName\tstring\n+\tAge\tint64\n }\n"
+    );
+
+    let diff = unified_diff("a\nb", "a\nb\n", "old", "new").expect("diff");
+    assert_eq!(
+        diff,
+        "--- old\n+++ new\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n"
+    );
+    assert_eq!(unified_diff("same\n", "same\n", "old", "new"), None);
+}
+
+#[test]
+fn unified_diff_handles_large_one_sided_changes() {
+    let generated: String = (0..5000).map(|index| format!("line {}\n", index)).collect();
+    let diff = unified_diff("", &generated, "old", "new").expect("diff");
+    assert!(diff.starts_with("--- old\n+++ new\n@@ -0,0 +1,5000 @@\n+line 0\n"));
+    assert_eq!(diff.lines().count(), 5003);
+
+    let diff = unified_diff(&generated, "", "old", "new").expect("diff");
+    assert!(diff.starts_with("--- old\n+++ new\n@@ -1,5000 +0,0 @@\n-line 0\n"));
+
+    let edited = generated.replace("line 10\n", "").replace("line 4000\n", "changed\n");
+    let diff = unified_diff(&generated, &edited, "old", "new").expect("diff");
+    assert_eq!(
+        diff,
+        "--- old\n+++ new\n@@ -8,7 +8,6 @@\n line 7\n line 8\n line 9\n-line 10\n line 11\n \
+         line 12\n line 13\n@@ -3998,7 +3997,7 @@\n line 3997\n line 3998\n line 3999\n\
+         -line 4000\n+changed\n line 4001\n line 4002\n line 4003\n"
+    );
+}
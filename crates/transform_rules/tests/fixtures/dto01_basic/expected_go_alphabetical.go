@@ -0,0 +1,20 @@
+package dto
+
+import "encoding/json"
+
+type RecordUser struct {
+	Age  int64            `json:"age"`
+	Name *json.RawMessage `json:"name,omitempty"`
+}
+
+type Record struct {
+	Active   bool             `json:"active"`
+	Class    *json.RawMessage `json:"class,omitempty"`
+	Id       string           `json:"id"`
+	Meta     *json.RawMessage `json:"meta,omitempty"`
+	Price    *float64         `json:"price,omitempty"`
+	Source   string           `json:"source"`
+	Status   string           `json:"status"`
+	User     RecordUser       `json:"user"`
+	UserName *json.RawMessage `json:"user-name,omitempty"`
+}
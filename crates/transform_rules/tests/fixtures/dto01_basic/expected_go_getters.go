@@ -0,0 +1,55 @@
+package dto
+
+import "encoding/json"
+
+type RecordUser struct {
+	Name *json.RawMessage `json:"name,omitempty"`
+	Age  int64            `json:"age"`
+}
+
+func (r RecordUser) GetName() json.RawMessage {
+	if r.Name != nil {
+		return *r.Name
+	}
+	return nil
+}
+
+type Record struct {
+	Id       string           `json:"id"`
+	User     RecordUser       `json:"user"`
+	Price    *float64         `json:"price,omitempty"`
+	Active   bool             `json:"active"`
+	Meta     *json.RawMessage `json:"meta,omitempty"`
+	UserName *json.RawMessage `json:"user-name,omitempty"`
+	Class    *json.RawMessage `json:"class,omitempty"`
+	Status   string           `json:"status"`
+	Source   string           `json:"source"`
+}
+
+func (r Record) GetPrice() float64 {
+	if r.Price != nil {
+		return *r.Price
+	}
+	return 0
+}
+
+func (r Record) GetMeta() json.RawMessage {
+	if r.Meta != nil {
+		return *r.Meta
+	}
+	return nil
+}
+
+func (r Record) GetUserName() json.RawMessage {
+	if r.UserName != nil {
+		return *r.UserName
+	}
+	return nil
+}
+
+func (r Record) GetClass() json.RawMessage {
+	if r.Class != nil {
+		return *r.Class
+	}
+	return nil
+}
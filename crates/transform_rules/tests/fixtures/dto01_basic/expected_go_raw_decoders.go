@@ -0,0 +1,48 @@
+package dto
+
+import "encoding/json"
+
+type RecordUser struct {
+	Name *json.RawMessage `json:"name,omitempty"`
+	Age  int64            `json:"age"`
+}
+
+func (r *RecordUser) DecodeName(v any) error {
+	if r.Name == nil {
+		return nil
+	}
+	return json.Unmarshal(*r.Name, v)
+}
+
+type Record struct {
+	Id       string           `json:"id"`
+	User     RecordUser       `json:"user"`
+	Price    *float64         `json:"price,omitempty"`
+	Active   bool             `json:"active"`
+	Meta     *json.RawMessage `json:"meta,omitempty"`
+	UserName *json.RawMessage `json:"user-name,omitempty"`
+	Class    *json.RawMessage `json:"class,omitempty"`
+	Status   string           `json:"status"`
+	Source   string           `json:"source"`
+}
+
+func (r *Record) DecodeMeta(v any) error {
+	if r.Meta == nil {
+		return nil
+	}
+	return json.Unmarshal(*r.Meta, v)
+}
+
+func (r *Record) DecodeUserName(v any) error {
+	if r.UserName == nil {
+		return nil
+	}
+	return json.Unmarshal(*r.UserName, v)
+}
+
+func (r *Record) DecodeClass(v any) error {
+	if r.Class == nil {
+		return nil
+	}
+	return json.Unmarshal(*r.Class, v)
+}
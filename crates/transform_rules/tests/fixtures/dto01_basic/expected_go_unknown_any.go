@@ -0,0 +1,18 @@
+package dto
+
+type RecordUser struct {
+	Name any   `json:"name,omitempty"`
+	Age  int64 `json:"age"`
+}
+
+type Record struct {
+	Id       string     `json:"id"`
+	User     RecordUser `json:"user"`
+	Price    *float64   `json:"price,omitempty"`
+	Active   bool       `json:"active"`
+	Meta     any        `json:"meta,omitempty"`
+	UserName any        `json:"user-name,omitempty"`
+	Class    any        `json:"class,omitempty"`
+	Status   string     `json:"status"`
+	Source   string     `json:"source"`
+}
@@ -0,0 +1,18 @@
+from typing import Optional, Any
+
+from pydantic import BaseModel, Field
+
+class RecordUser(BaseModel):
+    name: Optional[Any] = None
+    age: int
+
+class Record(BaseModel):
+    id: str
+    user: RecordUser
+    price: Optional[float] = None
+    active: bool
+    meta: Optional[Any] = None
+    user_name: Optional[Any] = Field(default=None, alias="user-name")
+    class_: Optional[Any] = Field(default=None, alias="class")
+    status: str = "active"
+    source: str
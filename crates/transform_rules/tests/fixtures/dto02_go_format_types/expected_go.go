@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Record struct {
+	Id        string           `json:"id"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt *time.Time       `json:"updated_at,omitempty"`
+	Birthday  *time.Time       `json:"birthday,omitempty"`
+	Email     *string          `json:"email,omitempty"`
+	Meta      *json.RawMessage `json:"meta,omitempty"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+type Record struct {
+	Id        string           `json:"id"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt *time.Time       `json:"updated_at,omitempty"`
+	Birthday  *civil.Date      `json:"birthday,omitempty"`
+	Email     *string          `json:"email,omitempty"`
+	Meta      *json.RawMessage `json:"meta,omitempty"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type RecordOwner struct {
+	DisplayName string `json:"display_name"`
+}
+
+type Record struct {
+	Id         string      `json:"id"`
+	UserName   *string     `json:"user-name,omitempty"`
+	Createdat  string      `json:"createdAt"`
+	Httpstatus *int64      `json:"HTTPStatus,omitempty"`
+	Owner      RecordOwner `json:"owner"`
+}
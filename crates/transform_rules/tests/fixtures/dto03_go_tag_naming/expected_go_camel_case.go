@@ -0,0 +1,13 @@
+package dto
+
+type RecordOwner struct {
+	DisplayName string `json:"displayName"`
+}
+
+type Record struct {
+	Id         string      `json:"id"`
+	UserName   *string     `json:"userName,omitempty"`
+	Createdat  string      `json:"createdAt"`
+	Httpstatus *int64      `json:"httpStatus,omitempty"`
+	Owner      RecordOwner `json:"owner"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type RecordOwner struct {
+	DisplayName string `json:"DisplayName"`
+}
+
+type Record struct {
+	Id         string      `json:"Id"`
+	UserName   *string     `json:"UserName,omitempty"`
+	Createdat  string      `json:"CreatedAt"`
+	Httpstatus *int64      `json:"HttpStatus,omitempty"`
+	Owner      RecordOwner `json:"Owner"`
+}
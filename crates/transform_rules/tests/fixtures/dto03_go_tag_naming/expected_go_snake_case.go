@@ -0,0 +1,13 @@
+package dto
+
+type RecordOwner struct {
+	DisplayName string `json:"display_name"`
+}
+
+type Record struct {
+	Id         string      `json:"id"`
+	UserName   *string     `json:"user_name,omitempty"`
+	Createdat  string      `json:"created_at"`
+	Httpstatus *int64      `json:"http_status,omitempty"`
+	Owner      RecordOwner `json:"owner"`
+}
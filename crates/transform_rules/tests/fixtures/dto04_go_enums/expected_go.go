@@ -0,0 +1,12 @@
+package dto
+
+type RecordUser struct {
+	Name string  `json:"name"`
+	Role *string `json:"role,omitempty"`
+}
+
+type Record struct {
+	Id     string     `json:"id"`
+	Status string     `json:"status"`
+	User   RecordUser `json:"user"`
+}
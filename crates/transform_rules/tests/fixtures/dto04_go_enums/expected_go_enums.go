@@ -0,0 +1,27 @@
+package dto
+
+type RecordUserRole string
+
+const (
+	RecordUserRoleAdmin    RecordUserRole = "admin"
+	RecordUserRoleReadOnly RecordUserRole = "read-only"
+)
+
+type RecordUser struct {
+	Name string          `json:"name"`
+	Role *RecordUserRole `json:"role,omitempty"`
+}
+
+type RecordStatus string
+
+const (
+	RecordStatusActive   RecordStatus = "active"
+	RecordStatusInactive RecordStatus = "inactive"
+	RecordStatusPending  RecordStatus = "pending"
+)
+
+type Record struct {
+	Id     string       `json:"id"`
+	Status RecordStatus `json:"status"`
+	User   RecordUser   `json:"user"`
+}
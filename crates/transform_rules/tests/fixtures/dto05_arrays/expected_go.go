@@ -0,0 +1,14 @@
+package dto
+
+type RecordItem struct {
+	Sku string `json:"sku"`
+	Qty *int64 `json:"qty,omitempty"`
+}
+
+type Record struct {
+	Id     string       `json:"id"`
+	Tags   []string     `json:"tags,omitempty"`
+	Scores []int64      `json:"scores"`
+	Items  []RecordItem `json:"items"`
+	Matrix [][]float64  `json:"matrix,omitempty"`
+}
@@ -0,0 +1,15 @@
+import java.util.List;
+import java.util.Optional;
+
+class RecordItem {
+    public String sku;
+    public Optional<Long> qty;
+}
+
+public class Record {
+    public String id;
+    public Optional<List<String>> tags;
+    public List<Long> scores;
+    public List<RecordItem> items;
+    public Optional<List<List<Double>>> matrix;
+}
@@ -0,0 +1,12 @@
+data class RecordItem(
+    val sku: String,
+    val qty: Long?
+)
+
+data class Record(
+    val id: String,
+    val tags: List<String>?,
+    val scores: List<Long>,
+    val items: List<RecordItem>,
+    val matrix: List<List<Double>>?
+)
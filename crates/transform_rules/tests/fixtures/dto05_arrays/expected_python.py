@@ -0,0 +1,15 @@
+from dataclasses import dataclass
+from typing import Optional, List
+
+@dataclass
+class RecordItem:
+    sku: str
+    qty: Optional[int] = None
+
+@dataclass
+class Record:
+    id: str
+    scores: List[int]
+    items: List[RecordItem]
+    tags: Optional[List[str]] = None
+    matrix: Optional[List[List[float]]] = None
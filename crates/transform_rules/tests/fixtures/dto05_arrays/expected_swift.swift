@@ -0,0 +1,12 @@
+struct RecordItem: Codable {
+    let sku: String
+    let qty: Int?
+}
+
+struct Record: Codable {
+    let id: String
+    let tags: [String]?
+    let scores: [Int]
+    let items: [RecordItem]
+    let matrix: [[Double]]?
+}
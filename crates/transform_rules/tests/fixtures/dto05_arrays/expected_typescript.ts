@@ -0,0 +1,12 @@
+export interface RecordItem {
+  sku: string;
+  qty?: number;
+}
+
+export interface Record {
+  id: string;
+  tags?: string[];
+  scores: number[];
+  items: RecordItem[];
+  matrix?: number[][];
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "encoding/json"
+
+type RecordOwnersValue struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+type Record struct {
+	Id         string                       `json:"id"`
+	Counts     map[string]int64             `json:"counts"`
+	Labels     map[string]string            `json:"labels,omitempty"`
+	Attributes map[string]json.RawMessage   `json:"attributes,omitempty"`
+	Owners     map[string]RecordOwnersValue `json:"owners,omitempty"`
+}
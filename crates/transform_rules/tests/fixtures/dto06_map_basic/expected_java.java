@@ -0,0 +1,17 @@
+import com.fasterxml.jackson.databind.JsonNode;
+import java.util.List;
+import java.util.Map;
+import java.util.Optional;
+
+class RecordOwnersValue {
+    public String name;
+    public Optional<List<String>> tags;
+}
+
+public class Record {
+    public String id;
+    public Map<String, Long> counts;
+    public Optional<Map<String, String>> labels;
+    public Optional<Map<String, JsonNode>> attributes;
+    public Optional<Map<String, RecordOwnersValue>> owners;
+}
@@ -0,0 +1,14 @@
+import com.fasterxml.jackson.databind.JsonNode
+
+data class RecordOwnersValue(
+    val name: String,
+    val tags: List<String>?
+)
+
+data class Record(
+    val id: String,
+    val counts: Map<String, Long>,
+    val labels: Map<String, String>?,
+    val attributes: Map<String, JsonNode>?,
+    val owners: Map<String, RecordOwnersValue>?
+)
@@ -0,0 +1,14 @@
+from typing import Optional, Any, List, Dict
+
+from pydantic import BaseModel
+
+class RecordOwnersValue(BaseModel):
+    name: str
+    tags: Optional[List[str]] = None
+
+class Record(BaseModel):
+    id: str
+    counts: Dict[str, int]
+    labels: Optional[Dict[str, str]] = None
+    attributes: Optional[Dict[str, Any]] = None
+    owners: Optional[Dict[str, RecordOwnersValue]] = None
@@ -0,0 +1,15 @@
+from dataclasses import dataclass
+from typing import Optional, Any, List, Dict
+
+@dataclass
+class RecordOwnersValue:
+    name: str
+    tags: Optional[List[str]] = None
+
+@dataclass
+class Record:
+    id: str
+    counts: Dict[str, int]
+    labels: Optional[Dict[str, str]] = None
+    attributes: Optional[Dict[str, Any]] = None
+    owners: Optional[Dict[str, RecordOwnersValue]] = None
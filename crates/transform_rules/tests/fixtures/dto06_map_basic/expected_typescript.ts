@@ -0,0 +1,12 @@
+export interface RecordOwnersValue {
+  name: string;
+  tags?: string[];
+}
+
+export interface Record {
+  id: string;
+  counts: { [key: string]: number };
+  labels?: { [key: string]: string };
+  attributes?: { [key: string]: unknown };
+  owners?: { [key: string]: RecordOwnersValue };
+}
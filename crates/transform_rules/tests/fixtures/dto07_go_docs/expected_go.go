@@ -0,0 +1,19 @@
+package dto
+
+type RecordProfile struct {
+	// Display name chosen by the user.
+	Nickname *string `json:"nickname,omitempty"`
+	Age      *int64  `json:"age,omitempty"`
+}
+
+// Record is a user synced from the upstream API.
+type Record struct {
+	// Stable identifier assigned by the upstream API.
+	Id string `json:"id"`
+	// Primary contact address.
+	//
+	// Empty when the user has not verified an address.
+	Email *string `json:"email,omitempty"`
+	// Public profile details.
+	Profile *RecordProfile `json:"profile,omitempty"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+type Record struct {
+	Status  string   `json:"status"`
+	Score   *float64 `json:"score,omitempty"`
+	Retries int64    `json:"retries"`
+	Tags    []string `json:"tags,omitempty"`
+	Note    *string  `json:"note,omitempty"`
+}
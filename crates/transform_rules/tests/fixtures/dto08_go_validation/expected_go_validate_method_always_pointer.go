@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+type Record struct {
+	Status  *string  `json:"status"`
+	Score   *float64 `json:"score,omitempty"`
+	Retries *int64   `json:"retries"`
+	Tags    []string `json:"tags,omitempty"`
+	Note    *string  `json:"note,omitempty"`
+}
+
+func (r Record) Validate() error {
+	var errs []error
+	if r.Status == nil {
+		errs = append(errs, errors.New("status is required"))
+	}
+	if r.Status != nil && utf8.RuneCountInString(*r.Status) > 64 {
+		errs = append(errs, errors.New("status length must be at most 64"))
+	}
+	if r.Score != nil && *r.Score < 0 {
+		errs = append(errs, errors.New("score must be at least 0"))
+	}
+	if r.Score != nil && *r.Score > 99.5 {
+		errs = append(errs, errors.New("score must be at most 99.5"))
+	}
+	if r.Retries == nil {
+		errs = append(errs, errors.New("retries is required"))
+	}
+	if r.Retries != nil && *r.Retries < 1 {
+		errs = append(errs, errors.New("retries must be at least 1"))
+	}
+	if r.Tags != nil && len(r.Tags) < 1 {
+		errs = append(errs, errors.New("tags length must be at least 1"))
+	}
+	return errors.Join(errs...)
+}
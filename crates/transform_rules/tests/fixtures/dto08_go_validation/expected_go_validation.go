@@ -0,0 +1,9 @@
+package dto
+
+type Record struct {
+	Status  string   `json:"status" validate:"required,max=64"`
+	Score   *float64 `json:"score,omitempty" validate:"omitempty,min=0,max=99.5"`
+	Retries int64    `json:"retries" validate:"required,min=1"`
+	Tags    []string `json:"tags,omitempty" validate:"omitempty,min=1"`
+	Note    *string  `json:"note,omitempty"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type Address struct {
+	City string  `json:"city"`
+	Zip  *string `json:"zip,omitempty"`
+}
+
+type UserProfile struct {
+	Bio   *string  `json:"bio,omitempty"`
+	Links []string `json:"links,omitempty"`
+}
+
+// A registered user.
+type User struct {
+	Id             string       `json:"id"`
+	Nickname       *string      `json:"nickname,omitempty"`
+	Age            *int64       `json:"age,omitempty"`
+	Score          *float64     `json:"score,omitempty"`
+	Active         *bool        `json:"active,omitempty"`
+	CreatedAt      *time.Time   `json:"created_at,omitempty"`
+	Address        Address      `json:"address"`
+	BillingAddress *Address     `json:"billing_address,omitempty"`
+	Status         *string      `json:"status,omitempty"`
+	Tags           []string     `json:"tags,omitempty"`
+	Profile        *UserProfile `json:"profile,omitempty"`
+}
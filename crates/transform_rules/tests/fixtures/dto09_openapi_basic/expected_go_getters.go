@@ -0,0 +1,101 @@
+package dto
+
+import "time"
+
+type Address struct {
+	City string  `json:"city"`
+	Zip  *string `json:"zip,omitempty"`
+}
+
+func (a Address) GetZip() string {
+	if a.Zip != nil {
+		return *a.Zip
+	}
+	return ""
+}
+
+type UserProfile struct {
+	Bio   *string  `json:"bio,omitempty"`
+	Links []string `json:"links,omitempty"`
+}
+
+func (u UserProfile) GetBio() string {
+	if u.Bio != nil {
+		return *u.Bio
+	}
+	return ""
+}
+
+// A registered user.
+type User struct {
+	Id             string       `json:"id"`
+	Nickname       *string      `json:"nickname,omitempty"`
+	Age            *int64       `json:"age,omitempty"`
+	Score          *float64     `json:"score,omitempty"`
+	Active         *bool        `json:"active,omitempty"`
+	CreatedAt      *time.Time   `json:"created_at,omitempty"`
+	Address        Address      `json:"address"`
+	BillingAddress *Address     `json:"billing_address,omitempty"`
+	Status         *string      `json:"status,omitempty"`
+	Tags           []string     `json:"tags,omitempty"`
+	Profile        *UserProfile `json:"profile,omitempty"`
+}
+
+func (u User) GetNickname() string {
+	if u.Nickname != nil {
+		return *u.Nickname
+	}
+	return ""
+}
+
+func (u User) GetAge() int64 {
+	if u.Age != nil {
+		return *u.Age
+	}
+	return 0
+}
+
+func (u User) GetScore() float64 {
+	if u.Score != nil {
+		return *u.Score
+	}
+	return 0
+}
+
+func (u User) GetActive() bool {
+	if u.Active != nil {
+		return *u.Active
+	}
+	return false
+}
+
+func (u User) GetCreatedAt() time.Time {
+	if u.CreatedAt != nil {
+		return *u.CreatedAt
+	}
+	var zero time.Time
+	return zero
+}
+
+func (u User) GetBillingAddress() Address {
+	if u.BillingAddress != nil {
+		return *u.BillingAddress
+	}
+	var zero Address
+	return zero
+}
+
+func (u User) GetStatus() string {
+	if u.Status != nil {
+		return *u.Status
+	}
+	return ""
+}
+
+func (u User) GetProfile() UserProfile {
+	if u.Profile != nil {
+		return *u.Profile
+	}
+	var zero UserProfile
+	return zero
+}
@@ -0,0 +1,29 @@
+import com.fasterxml.jackson.annotation.JsonProperty;
+import java.util.List;
+import java.util.Optional;
+
+class Address {
+    public String city;
+    public Optional<String> zip;
+}
+
+class UserProfile {
+    public Optional<String> bio;
+    public Optional<List<String>> links;
+}
+
+class User {
+    public String id;
+    public Optional<String> nickname;
+    public Optional<Long> age;
+    public Optional<Double> score;
+    public Optional<Boolean> active;
+    @JsonProperty("created_at")
+    public Optional<String> createdAt;
+    public Address address;
+    @JsonProperty("billing_address")
+    public Optional<Address> billingAddress;
+    public Optional<String> status;
+    public Optional<List<String>> tags;
+    public Optional<UserProfile> profile;
+}
@@ -0,0 +1,27 @@
+import com.fasterxml.jackson.annotation.JsonProperty
+
+data class Address(
+    val city: String,
+    val zip: String?
+)
+
+data class UserProfile(
+    val bio: String?,
+    val links: List<String>?
+)
+
+data class User(
+    val id: String,
+    val nickname: String?,
+    val age: Long?,
+    val score: Double?,
+    val active: Boolean?,
+    @JsonProperty("created_at")
+    val createdAt: String?,
+    val address: Address,
+    @JsonProperty("billing_address")
+    val billingAddress: Address?,
+    val status: String?,
+    val tags: List<String>?,
+    val profile: UserProfile?
+)
@@ -0,0 +1,26 @@
+from dataclasses import dataclass
+from typing import Optional, List
+
+@dataclass
+class Address:
+    city: str
+    zip: Optional[str] = None
+
+@dataclass
+class UserProfile:
+    bio: Optional[str] = None
+    links: Optional[List[str]] = None
+
+@dataclass
+class User:
+    id: str
+    address: Address
+    nickname: Optional[str] = None
+    age: Optional[int] = None
+    score: Optional[float] = None
+    active: Optional[bool] = None
+    created_at: Optional[str] = None
+    billing_address: Optional[Address] = None
+    status: Optional[str] = None
+    tags: Optional[List[str]] = None
+    profile: Optional[UserProfile] = None
@@ -0,0 +1,28 @@
+struct Address: Codable {
+    let city: String
+    let zip: String?
+}
+
+struct UserProfile: Codable {
+    let bio: String?
+    let links: [String]?
+}
+
+struct User: Codable {
+    let id: String
+    let nickname: String?
+    let age: Int?
+    let score: Double?
+    let active: Bool?
+    let createdAt: String?
+    let address: Address
+    let billingAddress: Address?
+    let status: String?
+    let tags: [String]?
+    let profile: UserProfile?
+
+    enum CodingKeys: String, CodingKey {
+        case createdAt = "created_at"
+        case billingAddress = "billing_address"
+    }
+}
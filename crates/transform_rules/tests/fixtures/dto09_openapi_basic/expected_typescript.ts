@@ -0,0 +1,25 @@
+export interface Address {
+  city: string;
+  zip?: string;
+}
+
+export interface UserProfile {
+  bio?: string;
+  links?: string[];
+}
+
+export interface User {
+  id: string;
+  nickname?: string;
+  age?: number;
+  score?: number;
+  active?: boolean;
+  /** json: "created_at" */
+  createdAt?: string;
+  address: Address;
+  /** json: "billing_address" */
+  billingAddress?: Address;
+  status?: string;
+  tags?: string[];
+  profile?: UserProfile;
+}
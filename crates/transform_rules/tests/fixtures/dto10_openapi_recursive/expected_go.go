@@ -0,0 +1,18 @@
+package dto
+
+type TreeNode struct {
+	Name     string     `json:"name"`
+	Parent   *TreeNode  `json:"parent,omitempty"`
+	Children []TreeNode `json:"children"`
+}
+
+type Employee struct {
+	Name       string      `json:"name"`
+	Department *Department `json:"department,omitempty"`
+	Reports    []Employee  `json:"reports,omitempty"`
+}
+
+type Department struct {
+	Name    string    `json:"name"`
+	Manager *Employee `json:"manager"`
+}
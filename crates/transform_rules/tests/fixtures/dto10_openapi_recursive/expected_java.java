@@ -0,0 +1,19 @@
+import java.util.List;
+import java.util.Optional;
+
+class TreeNode {
+    public String name;
+    public Optional<TreeNode> parent;
+    public List<TreeNode> children;
+}
+
+class Employee {
+    public String name;
+    public Optional<Department> department;
+    public Optional<List<Employee>> reports;
+}
+
+class Department {
+    public String name;
+    public Employee manager;
+}
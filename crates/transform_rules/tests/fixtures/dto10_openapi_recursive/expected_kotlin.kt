@@ -0,0 +1,16 @@
+data class TreeNode(
+    val name: String,
+    val parent: TreeNode?,
+    val children: List<TreeNode>
+)
+
+data class Employee(
+    val name: String,
+    val department: Department?,
+    val reports: List<Employee>?
+)
+
+data class Department(
+    val name: String,
+    val manager: Employee
+)
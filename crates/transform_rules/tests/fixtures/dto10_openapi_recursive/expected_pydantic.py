@@ -0,0 +1,19 @@
+from __future__ import annotations
+
+from typing import Optional, List
+
+from pydantic import BaseModel
+
+class TreeNode(BaseModel):
+    name: str
+    parent: Optional[TreeNode] = None
+    children: List[TreeNode]
+
+class Employee(BaseModel):
+    name: str
+    department: Optional[Department] = None
+    reports: Optional[List[Employee]] = None
+
+class Department(BaseModel):
+    name: str
+    manager: Employee
@@ -0,0 +1,21 @@
+from __future__ import annotations
+
+from dataclasses import dataclass
+from typing import Optional, List
+
+@dataclass
+class TreeNode:
+    name: str
+    children: List[TreeNode]
+    parent: Optional[TreeNode] = None
+
+@dataclass
+class Employee:
+    name: str
+    department: Optional[Department] = None
+    reports: Optional[List[Employee]] = None
+
+@dataclass
+class Department:
+    name: str
+    manager: Employee
@@ -0,0 +1,24 @@
+use serde::{Deserialize, Serialize};
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct TreeNode {
+    pub name: String,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub parent: Option<Box<TreeNode>>,
+    pub children: Vec<TreeNode>,
+}
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Employee {
+    pub name: String,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub department: Option<Box<Department>>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub reports: Option<Vec<Employee>>,
+}
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Department {
+    pub name: String,
+    pub manager: Box<Employee>,
+}
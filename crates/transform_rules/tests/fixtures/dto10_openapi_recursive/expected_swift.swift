@@ -0,0 +1,16 @@
+final class TreeNode: Codable {
+    let name: String
+    let parent: TreeNode?
+    let children: [TreeNode]
+}
+
+final class Employee: Codable {
+    let name: String
+    let department: Department?
+    let reports: [Employee]?
+}
+
+final class Department: Codable {
+    let name: String
+    let manager: Employee
+}
@@ -0,0 +1,16 @@
+export interface TreeNode {
+  name: string;
+  parent?: TreeNode;
+  children: TreeNode[];
+}
+
+export interface Employee {
+  name: string;
+  department?: Department;
+  reports?: Employee[];
+}
+
+export interface Department {
+  name: string;
+  manager: Employee;
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "encoding/json"
+
+type Cat struct {
+	Kind  string `json:"kind"`
+	Lives *int64 `json:"lives,omitempty"`
+}
+
+type Dog struct {
+	Kind string `json:"kind"`
+	Good *bool  `json:"good,omitempty"`
+}
+
+type Owner struct {
+	Name     string           `json:"name"`
+	Pet      json.RawMessage  `json:"pet"`
+	Favorite *json.RawMessage `json:"favorite,omitempty"`
+}
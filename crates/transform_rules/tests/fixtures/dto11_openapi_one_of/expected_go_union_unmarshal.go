@@ -0,0 +1,113 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+type Cat struct {
+	Kind  string `json:"kind"`
+	Lives *int64 `json:"lives,omitempty"`
+}
+
+type Dog struct {
+	Kind string `json:"kind"`
+	Good *bool  `json:"good,omitempty"`
+}
+
+type Pet struct {
+	Kind string `json:"kind"`
+	Cat  *Cat   `json:"-"`
+	Dog  *Dog   `json:"-"`
+}
+
+func (p Pet) MarshalJSON() ([]byte, error) {
+	var (
+		variant any
+		tag     json.RawMessage
+	)
+	switch {
+	case p.Cat != nil:
+		variant, tag = p.Cat, json.RawMessage(`"cat"`)
+	case p.Dog != nil:
+		variant, tag = p.Dog, json.RawMessage(`"dog"`)
+	default:
+		return []byte("null"), nil
+	}
+	data, err := json.Marshal(variant)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	fields["kind"] = tag
+	return json.Marshal(fields)
+}
+
+func (p *Pet) UnmarshalJSON(data []byte) error {
+	*p = Pet{}
+	if string(data) == "null" {
+		return nil
+	}
+	var probe struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	p.Kind = probe.Kind
+	switch probe.Kind {
+	case "cat":
+		p.Cat = new(Cat)
+		return json.Unmarshal(data, p.Cat)
+	case "dog":
+		p.Dog = new(Dog)
+		return json.Unmarshal(data, p.Dog)
+	}
+	return nil
+}
+
+type OwnerFavorite struct {
+	Cat *Cat `json:"-"`
+	Dog *Dog `json:"-"`
+}
+
+func (o OwnerFavorite) MarshalJSON() ([]byte, error) {
+	switch {
+	case o.Cat != nil:
+		return json.Marshal(o.Cat)
+	case o.Dog != nil:
+		return json.Marshal(o.Dog)
+	}
+	return []byte("null"), nil
+}
+
+func (o *OwnerFavorite) UnmarshalJSON(data []byte) error {
+	*o = OwnerFavorite{}
+	if string(data) == "null" {
+		return nil
+	}
+	decode := func(variant any) bool {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(variant) == nil
+	}
+	if variant := new(Cat); decode(variant) {
+		o.Cat = variant
+		return nil
+	}
+	if variant := new(Dog); decode(variant) {
+		o.Dog = variant
+		return nil
+	}
+	return errors.New("no OwnerFavorite variant matches")
+}
+
+type Owner struct {
+	Name     string         `json:"name"`
+	Pet      Pet            `json:"pet"`
+	Favorite *OwnerFavorite `json:"favorite,omitempty"`
+}
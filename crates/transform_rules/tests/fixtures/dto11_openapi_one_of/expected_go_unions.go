@@ -0,0 +1,28 @@
+package dto
+
+type Cat struct {
+	Kind  string `json:"kind"`
+	Lives *int64 `json:"lives,omitempty"`
+}
+
+type Dog struct {
+	Kind string `json:"kind"`
+	Good *bool  `json:"good,omitempty"`
+}
+
+type Pet struct {
+	Kind string `json:"kind"`
+	Cat  *Cat   `json:"-"`
+	Dog  *Dog   `json:"-"`
+}
+
+type OwnerFavorite struct {
+	Cat *Cat `json:"-"`
+	Dog *Dog `json:"-"`
+}
+
+type Owner struct {
+	Name     string         `json:"name"`
+	Pet      Pet            `json:"pet"`
+	Favorite *OwnerFavorite `json:"favorite,omitempty"`
+}
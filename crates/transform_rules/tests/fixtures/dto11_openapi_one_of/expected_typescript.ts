@@ -0,0 +1,15 @@
+export interface Cat {
+  kind: string;
+  lives?: number;
+}
+
+export interface Dog {
+  kind: string;
+  good?: boolean;
+}
+
+export interface Owner {
+  name: string;
+  pet: unknown;
+  favorite?: unknown;
+}
@@ -0,0 +1,16 @@
+package dto
+
+type Record struct {
+	Count      int64   `json:"count"`
+	Small      int8    `json:"small"`
+	Short      int16   `json:"short"`
+	Code       int32   `json:"code"`
+	Total      int64   `json:"total"`
+	Size       uint    `json:"size"`
+	Flags      uint8   `json:"flags"`
+	Port       uint16  `json:"port"`
+	Crc        uint32  `json:"crc"`
+	Offset     uint64  `json:"offset"`
+	RetryLimit *int32  `json:"retry_limit,omitempty"`
+	Quota      *uint64 `json:"quota,omitempty"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "encoding/json"
+
+type Record struct {
+	Count      json.Number  `json:"count"`
+	Small      json.Number  `json:"small"`
+	Short      json.Number  `json:"short"`
+	Code       json.Number  `json:"code"`
+	Total      json.Number  `json:"total"`
+	Size       json.Number  `json:"size"`
+	Flags      json.Number  `json:"flags"`
+	Port       json.Number  `json:"port"`
+	Crc        json.Number  `json:"crc"`
+	Offset     json.Number  `json:"offset"`
+	RetryLimit *json.Number `json:"retry_limit,omitempty"`
+	Quota      *json.Number `json:"quota,omitempty"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+type Address struct {
+	Street  string  `json:"street"`
+	City    *string `json:"city,omitempty"`
+	Country *string `json:"country,omitempty"`
+}
+
+type Customer struct {
+	Name     string    `json:"name"`
+	Address  *Address  `json:"address,omitempty"`
+	Referrer *Customer `json:"referrer,omitempty"`
+}
+
+type LineItem struct {
+	Sku      string `json:"sku"`
+	Quantity int32  `json:"quantity"`
+}
+
+type Order struct {
+	Id       string     `json:"id"`
+	Customer Customer   `json:"customer"`
+	Shipping *Address   `json:"shipping,omitempty"`
+	Billing  *Address   `json:"billing,omitempty"`
+	Items    []LineItem `json:"items,omitempty"`
+}
@@ -0,0 +1,24 @@
+export interface Address {
+  street: string;
+  city?: string;
+  country?: string;
+}
+
+export interface Customer {
+  name: string;
+  address?: Address;
+  referrer?: Customer;
+}
+
+export interface LineItem {
+  sku: string;
+  quantity: number;
+}
+
+export interface Order {
+  id: string;
+  customer: Customer;
+  shipping?: Address;
+  billing?: Address;
+  items?: LineItem[];
+}
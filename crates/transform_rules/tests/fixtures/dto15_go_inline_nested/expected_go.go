@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+type RecordUserAddressGeo struct {
+	Lat *float64 `json:"lat,omitempty"`
+	Lng *float64 `json:"lng,omitempty"`
+}
+
+type RecordUserAddress struct {
+	City string                `json:"city"`
+	Geo  *RecordUserAddressGeo `json:"geo,omitempty"`
+}
+
+type RecordUser struct {
+	Name     string            `json:"name"`
+	Address  RecordUserAddress `json:"address"`
+	Verified bool              `json:"verified"`
+}
+
+type Record struct {
+	Id        string     `json:"id"`
+	User      RecordUser `json:"user"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
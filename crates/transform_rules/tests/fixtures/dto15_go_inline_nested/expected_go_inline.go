@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+type Record struct {
+	Id   string `json:"id"`
+	User struct {
+		Name    string `json:"name"`
+		Address struct {
+			City string `json:"city"`
+			Geo  *struct {
+				Lat *float64 `json:"lat,omitempty"`
+				Lng *float64 `json:"lng,omitempty"`
+			} `json:"geo,omitempty"`
+		} `json:"address"`
+		Verified bool `json:"verified"`
+	} `json:"user"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
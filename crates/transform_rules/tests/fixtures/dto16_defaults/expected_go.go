@@ -0,0 +1,20 @@
+package dto
+
+import "encoding/json"
+
+type Settings struct {
+	Name    string           `json:"name"`
+	Retries int32            `json:"retries"`
+	Ratio   float64          `json:"ratio"`
+	Enabled bool             `json:"enabled"`
+	Mode    string           `json:"mode"`
+	Tags    []string         `json:"tags"`
+	Timeout *int64           `json:"timeout,omitempty"`
+	Label   *string          `json:"label,omitempty"`
+	Extra   *json.RawMessage `json:"extra,omitempty"`
+	Owner   *string          `json:"owner,omitempty"`
+}
+
+type Limits struct {
+	Max *int64 `json:"max,omitempty"`
+}
@@ -0,0 +1,48 @@
+package dto
+
+import "encoding/json"
+
+type SettingsMode string
+
+const (
+	SettingsModeFast SettingsMode = "fast"
+	SettingsModeSafe SettingsMode = "safe"
+)
+
+type Settings struct {
+	Name    string           `json:"name"`
+	Retries int32            `json:"retries"`
+	Ratio   float64          `json:"ratio"`
+	Enabled bool             `json:"enabled"`
+	Mode    SettingsMode     `json:"mode"`
+	Tags    []string         `json:"tags"`
+	Timeout *int64           `json:"timeout,omitempty"`
+	Label   *string          `json:"label,omitempty"`
+	Extra   *json.RawMessage `json:"extra,omitempty"`
+	Owner   *string          `json:"owner,omitempty"`
+}
+
+func NewSettings() *Settings {
+	defaultTimeout := int64(30)
+	defaultLabel := "none"
+	defaultExtra := json.RawMessage("{\"k\":[1,null]}")
+	return &Settings{
+		Name:    "primary \"node\"",
+		Retries: 3,
+		Ratio:   0.5,
+		Enabled: true,
+		Mode:    SettingsModeSafe,
+		Tags:    []string{"a", "b"},
+		Timeout: &defaultTimeout,
+		Label:   &defaultLabel,
+		Extra:   &defaultExtra,
+	}
+}
+
+type Limits struct {
+	Max *int64 `json:"max,omitempty"`
+}
+
+func NewLimits() *Limits {
+	return &Limits{}
+}
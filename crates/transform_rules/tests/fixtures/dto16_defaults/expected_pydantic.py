@@ -0,0 +1,18 @@
+from typing import Optional, Any, List
+
+from pydantic import BaseModel
+
+class Settings(BaseModel):
+    name: str = "primary \"node\""
+    retries: int = 3
+    ratio: float = 0.5
+    enabled: bool = True
+    mode: str = "safe"
+    tags: List[str] = ["a", "b"]
+    timeout: Optional[int] = 30
+    label: Optional[str] = "none"
+    extra: Optional[Any] = {"k": [1, None]}
+    owner: Optional[str] = None
+
+class Limits(BaseModel):
+    max: Optional[int] = None
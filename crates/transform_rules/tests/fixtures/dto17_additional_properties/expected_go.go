@@ -0,0 +1,15 @@
+package dto
+
+type Customer struct {
+	Name string `json:"name"`
+}
+
+type Item struct {
+	Sku *string `json:"sku,omitempty"`
+}
+
+type Order struct {
+	Id       string   `json:"id"`
+	Customer Customer `json:"customer"`
+	Items    []Item   `json:"items,omitempty"`
+}
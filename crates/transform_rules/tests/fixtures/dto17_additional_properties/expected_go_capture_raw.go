@@ -0,0 +1,103 @@
+package dto
+
+import "encoding/json"
+
+type Customer struct {
+	Name  string                     `json:"name"`
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (c Customer) MarshalJSON() ([]byte, error) {
+	type alias Customer
+	data, err := json.Marshal(alias(c))
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Extra) == 0 {
+		return data, nil
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range c.Extra {
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+func (c *Customer) UnmarshalJSON(data []byte) error {
+	type alias Customer
+	var decoded alias
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, key := range []string{"name"} {
+		delete(fields, key)
+	}
+	decoded.Extra = nil
+	if len(fields) > 0 {
+		decoded.Extra = fields
+	}
+	*c = Customer(decoded)
+	return nil
+}
+
+type Item struct {
+	Sku *string `json:"sku,omitempty"`
+}
+
+type Order struct {
+	Id       string                     `json:"id"`
+	Customer Customer                   `json:"customer"`
+	Items    []Item                     `json:"items,omitempty"`
+	Extra    map[string]json.RawMessage `json:"-"`
+}
+
+func (o Order) MarshalJSON() ([]byte, error) {
+	type alias Order
+	data, err := json.Marshal(alias(o))
+	if err != nil {
+		return nil, err
+	}
+	if len(o.Extra) == 0 {
+		return data, nil
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range o.Extra {
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+func (o *Order) UnmarshalJSON(data []byte) error {
+	type alias Order
+	var decoded alias
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, key := range []string{"id", "customer", "items"} {
+		delete(fields, key)
+	}
+	decoded.Extra = nil
+	if len(fields) > 0 {
+		decoded.Extra = fields
+	}
+	*o = Order(decoded)
+	return nil
+}
@@ -0,0 +1,12 @@
+package dto
+
+type Record struct {
+	UserName      string `json:"user-name"`
+	UserName2     string `json:"user_name"`
+	UserName3     string `json:"USER_NAME"`
+	UserName22    string `json:"user_name2"`
+	Type          string `json:"type"`
+	Func          string `json:"func"`
+	Field1st      int64  `json:"1st"`
+	Field2ndPlace int64  `json:"2nd-place"`
+}
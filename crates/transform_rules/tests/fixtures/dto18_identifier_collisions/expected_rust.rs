@@ -0,0 +1,19 @@
+use serde::{Deserialize, Serialize};
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Record {
+    #[serde(rename = "user-name")]
+    pub user_name: String,
+    #[serde(rename = "user_name")]
+    pub user_name_2: String,
+    #[serde(rename = "USER_NAME")]
+    pub user_name_3: String,
+    pub user_name2: String,
+    #[serde(rename = "type")]
+    pub type_: String,
+    pub func: String,
+    #[serde(rename = "1st")]
+    pub _1st: i64,
+    #[serde(rename = "2nd-place")]
+    pub _2nd_place: i64,
+}
@@ -0,0 +1,11 @@
+package dto
+
+type RecordUser struct {
+	Id   string  `json:"id"`
+	Name *string `json:"name,omitempty"`
+}
+
+type Record struct {
+	Email string     `json:"email"`
+	User  RecordUser `json:"user"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+type RecordUser struct {
+	Id   string  `json:"id"`
+	Name *string `json:"name,omitempty"`
+}
+
+type Record struct {
+	Email string     `json:"email"`
+	User  RecordUser `json:"user"`
+}
+
+type Record2 struct {
+	Id    string     `json:"id"`
+	User  RecordUser `json:"user"`
+	Total float64    `json:"total"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+type RecordUser struct {
+	Id   string  `json:"id"`
+	Name *string `json:"name,omitempty"`
+}
+
+type Record struct {
+	Id    string     `json:"id"`
+	User  RecordUser `json:"user"`
+	Total float64    `json:"total"`
+}
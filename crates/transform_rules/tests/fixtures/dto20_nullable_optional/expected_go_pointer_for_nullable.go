@@ -0,0 +1,11 @@
+package dto
+
+// Partial update; explicit null clears a field.
+type ProfilePatch struct {
+	Id        string   `json:"id"`
+	Bio       string   `json:"bio,omitempty"`
+	Nickname  *string  `json:"nickname"`
+	AvatarUrl *string  `json:"avatar_url"`
+	Age       *int64   `json:"age,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
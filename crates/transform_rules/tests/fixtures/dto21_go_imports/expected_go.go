@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	dec "github.com/shopspring/decimal"
+)
+
+type Record struct {
+	Id        uuid.UUID    `json:"id"`
+	CreatedAt time.Time    `json:"created_at"`
+	Price     dec.Decimal  `json:"price"`
+	Discount  *dec.Decimal `json:"discount,omitempty"`
+}
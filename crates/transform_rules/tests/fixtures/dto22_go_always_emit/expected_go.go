@@ -0,0 +1,8 @@
+package dto
+
+type Record struct {
+	Id       string   `json:"id"`
+	Active   bool     `json:"active"`
+	Verified *bool    `json:"verified,omitempty"`
+	Tags     []string `json:"tags"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+type Base struct {
+	Id        string     `json:"id"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+type Audited struct {
+	Base
+	UpdatedBy *string `json:"updated_by,omitempty"`
+}
+
+type Record struct {
+	Audited
+	Name string `json:"name"`
+	// Owning base record.
+	Owner *Base   `json:"owner,omitempty"`
+	Extra *string `json:"extra,omitempty"`
+}
@@ -0,0 +1,24 @@
+export interface Base {
+  id: string;
+  /** json: "created_at" */
+  createdAt?: string;
+}
+
+export interface Audited {
+  id: string;
+  /** json: "created_at" */
+  createdAt?: string;
+  /** json: "updated_by" */
+  updatedBy?: string;
+}
+
+export interface Record {
+  id: string;
+  /** json: "created_at" */
+  createdAt?: string;
+  /** json: "updated_by" */
+  updatedBy?: string;
+  name: string;
+  owner?: Base;
+  extra?: string;
+}
@@ -0,0 +1,8 @@
+package dto
+
+type Attachment struct {
+	Name      string `json:"name"`
+	Checksum  []byte `json:"checksum"`
+	Thumbnail []byte `json:"thumbnail,omitempty"`
+	Content   []byte `json:"content,omitempty"`
+}
@@ -0,0 +1,40 @@
+// ==========================================================
+// Package dto: generated from the Orders API schema
+// ==========================================================
+
+package dto
+
+import (
+	"time"
+)
+
+// ---- Customer ----
+type Customer struct {
+	Name string `json:"name"`
+}
+
+// ---- Order ----
+// A placed order.
+type Order struct {
+	Id string `json:"id"`
+	PlacedAt *time.Time `json:"placed_at,omitempty"` // optional
+	Customer Customer `json:"customer"`
+	// Free-form note from the customer.
+	// Shown on the packing slip.
+	Note *string `json:"note,omitempty"` // optional
+}
+
+func (o Order) GetPlacedAt() time.Time {
+	if o.PlacedAt != nil {
+		return *o.PlacedAt
+	}
+	var zero time.Time
+	return zero
+}
+
+func (o Order) GetNote() string {
+	if o.Note != nil {
+		return *o.Note
+	}
+	return ""
+}
@@ -0,0 +1,9 @@
+package dto
+
+import "encoding/json"
+
+type Series struct {
+	Name    string            `json:"name"`
+	Samples []json.RawMessage `json:"samples"`
+	Range   *json.RawMessage  `json:"range,omitempty"`
+}
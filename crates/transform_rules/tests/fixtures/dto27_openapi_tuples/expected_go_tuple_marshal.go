@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Sample struct {
+	Item0 string
+	Item1 float64
+}
+
+func (s Sample) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{s.Item0, s.Item1})
+}
+
+func (s *Sample) UnmarshalJSON(data []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	if len(items) != 2 {
+		return fmt.Errorf("expected 2 items for Sample, got %d", len(items))
+	}
+	if err := json.Unmarshal(items[0], &s.Item0); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(items[1], &s.Item1); err != nil {
+		return err
+	}
+	return nil
+}
+
+type SeriesRange struct {
+	Item0 int64
+	Item1 int64
+}
+
+func (s SeriesRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{s.Item0, s.Item1})
+}
+
+func (s *SeriesRange) UnmarshalJSON(data []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	if len(items) != 2 {
+		return fmt.Errorf("expected 2 items for SeriesRange, got %d", len(items))
+	}
+	if err := json.Unmarshal(items[0], &s.Item0); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(items[1], &s.Item1); err != nil {
+		return err
+	}
+	return nil
+}
+
+type Series struct {
+	Name    string       `json:"name"`
+	Samples []Sample     `json:"samples"`
+	Range   *SeriesRange `json:"range,omitempty"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+type Sample struct {
+	Item0 string
+	Item1 float64
+}
+
+type SeriesRange struct {
+	Item0 int64
+	Item1 int64
+}
+
+type Series struct {
+	Name    string       `json:"name"`
+	Samples []Sample     `json:"samples"`
+	Range   *SeriesRange `json:"range,omitempty"`
+}
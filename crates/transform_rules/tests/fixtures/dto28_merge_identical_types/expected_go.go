@@ -0,0 +1,23 @@
+package dto
+
+type RecordUser struct {
+	Name  string  `json:"name"`
+	Email *string `json:"email,omitempty"`
+}
+
+type RecordOwner struct {
+	Name  string  `json:"name"`
+	Email *string `json:"email,omitempty"`
+}
+
+type RecordReviewer struct {
+	Name  *string `json:"name,omitempty"`
+	Email *string `json:"email,omitempty"`
+}
+
+type Record struct {
+	Id       string          `json:"id"`
+	User     RecordUser      `json:"user"`
+	Owner    RecordOwner     `json:"owner"`
+	Reviewer *RecordReviewer `json:"reviewer,omitempty"`
+}
@@ -0,0 +1,16 @@
+export interface RecordUser {
+  name: string;
+  email?: string;
+}
+
+export interface RecordReviewer {
+  name?: string;
+  email?: string;
+}
+
+export interface Record {
+  id: string;
+  user: RecordUser;
+  owner: RecordUser;
+  reviewer?: RecordReviewer;
+}
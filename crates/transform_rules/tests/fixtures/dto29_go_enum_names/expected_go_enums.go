@@ -0,0 +1,29 @@
+package dto
+
+type RecordStatus string
+
+const (
+	RecordStatusTodo   RecordStatus = "todo"
+	StatusInProgress   RecordStatus = "in-progress"
+	RecordStatusOnHold RecordStatus = "on hold"
+	RecordStatusDone   RecordStatus = "done"
+)
+
+type RecordCode int32
+
+const (
+	RecordCode200   RecordCode = 200
+	RecordCode404   RecordCode = 404
+	CodeServerError RecordCode = 500
+)
+
+type Record struct {
+	Status RecordStatus `json:"status"`
+	Code   RecordCode   `json:"code"`
+}
+
+func NewRecord() *Record {
+	return &Record{
+		Code: RecordCode200,
+	}
+}
@@ -0,0 +1,20 @@
+package dto
+
+type RecordProfile struct {
+	Bio *string `json:"bio,omitempty"`
+	// Deprecated: Links moved to profile.links.
+	Website *string `json:"website,omitempty"`
+}
+
+type Record struct {
+	Id string `json:"id"`
+	// Name shown in the UI.
+	DisplayName *string `json:"display_name,omitempty"`
+	// Full name as entered at sign-up.
+	//
+	// Deprecated: Use display_name instead.
+	Name *string `json:"name,omitempty"`
+	// Deprecated: Do not use.
+	LegacyCode int64          `json:"legacy-code"`
+	Profile    *RecordProfile `json:"profile,omitempty"`
+}
@@ -0,0 +1,20 @@
+import com.fasterxml.jackson.annotation.JsonProperty;
+import java.util.Optional;
+
+class RecordProfile {
+    public Optional<String> bio;
+    @Deprecated
+    public Optional<String> website;
+}
+
+public class Record {
+    public String id;
+    @JsonProperty("display_name")
+    public Optional<String> displayName;
+    @Deprecated
+    public Optional<String> name;
+    @Deprecated
+    @JsonProperty("legacy-code")
+    public Long legacyCode;
+    public Optional<RecordProfile> profile;
+}
@@ -0,0 +1,19 @@
+import com.fasterxml.jackson.annotation.JsonProperty
+
+data class RecordProfile(
+    val bio: String?,
+    @Deprecated("Links moved to profile.links.")
+    val website: String?
+)
+
+data class Record(
+    val id: String,
+    @JsonProperty("display_name")
+    val displayName: String?,
+    @Deprecated("Use display_name instead.")
+    val name: String?,
+    @Deprecated("Do not use.")
+    @JsonProperty("legacy-code")
+    val legacyCode: Long,
+    val profile: RecordProfile?
+)
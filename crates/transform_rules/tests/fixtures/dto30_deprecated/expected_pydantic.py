@@ -0,0 +1,14 @@
+from typing import Optional
+
+from pydantic import BaseModel, Field
+
+class RecordProfile(BaseModel):
+    bio: Optional[str] = None
+    website: Optional[str] = Field(default=None, deprecated="Links moved to profile.links.")
+
+class Record(BaseModel):
+    id: str
+    display_name: Optional[str] = None
+    name: Optional[str] = Field(default=None, deprecated="Use display_name instead.")
+    legacy_code: int = Field(alias="legacy-code", deprecated="Do not use.")
+    profile: Optional[RecordProfile] = None
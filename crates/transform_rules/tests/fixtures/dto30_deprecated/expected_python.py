@@ -0,0 +1,19 @@
+from dataclasses import dataclass, field
+from typing import Optional
+
+@dataclass
+class RecordProfile:
+    bio: Optional[str] = None
+    # Deprecated: Links moved to profile.links.
+    website: Optional[str] = None
+
+@dataclass
+class Record:
+    id: str
+    # Deprecated: Do not use.
+    # json: "legacy-code"
+    legacy_code: int = field(metadata={"json_key": "legacy-code"})
+    display_name: Optional[str] = None
+    # Deprecated: Use display_name instead.
+    name: Optional[str] = None
+    profile: Optional[RecordProfile] = None
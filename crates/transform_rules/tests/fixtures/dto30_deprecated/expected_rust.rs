@@ -0,0 +1,25 @@
+use serde::{Deserialize, Serialize};
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct RecordProfile {
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub bio: Option<String>,
+    #[deprecated(note = "Links moved to profile.links.")]
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub website: Option<String>,
+}
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Record {
+    pub id: String,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub display_name: Option<String>,
+    #[deprecated(note = "Use display_name instead.")]
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub name: Option<String>,
+    #[deprecated(note = "Do not use.")]
+    #[serde(rename = "legacy-code")]
+    pub legacy_code: i64,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub profile: Option<RecordProfile>,
+}
@@ -0,0 +1,20 @@
+struct RecordProfile: Codable {
+    let bio: String?
+    @available(*, deprecated, message: "Links moved to profile.links.")
+    let website: String?
+}
+
+struct Record: Codable {
+    let id: String
+    let displayName: String?
+    @available(*, deprecated, message: "Use display_name instead.")
+    let name: String?
+    @available(*, deprecated, message: "Do not use.")
+    let legacyCode: Int
+    let profile: RecordProfile?
+
+    enum CodingKeys: String, CodingKey {
+        case displayName = "display_name"
+        case legacyCode = "legacy-code"
+    }
+}
@@ -0,0 +1,19 @@
+export interface RecordProfile {
+  bio?: string;
+  /** @deprecated Links moved to profile.links. */
+  website?: string;
+}
+
+export interface Record {
+  id: string;
+  /** json: "display_name" */
+  displayName?: string;
+  /** @deprecated Use display_name instead. */
+  name?: string;
+  /**
+   * json: "legacy-code"
+   * @deprecated Do not use.
+   */
+  legacyCode: number;
+  profile?: RecordProfile;
+}
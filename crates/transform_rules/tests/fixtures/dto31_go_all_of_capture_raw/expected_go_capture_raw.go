@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Base struct {
+	Id    string                     `json:"id"`
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (b Base) MarshalJSON() ([]byte, error) {
+	type alias Base
+	data, err := json.Marshal(alias(b))
+	if err != nil {
+		return nil, err
+	}
+	if len(b.Extra) == 0 {
+		return data, nil
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range b.Extra {
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+func (b *Base) UnmarshalJSON(data []byte) error {
+	type alias Base
+	var decoded alias
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, key := range []string{"id"} {
+		delete(fields, key)
+	}
+	decoded.Extra = nil
+	if len(fields) > 0 {
+		decoded.Extra = fields
+	}
+	*b = Base(decoded)
+	return nil
+}
+
+type Stamp struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+type Record struct {
+	Stamp
+	Id    string                     `json:"id"`
+	Name  string                     `json:"name"`
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r Record) MarshalJSON() ([]byte, error) {
+	type alias Record
+	data, err := json.Marshal(alias(r))
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Extra) == 0 {
+		return data, nil
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range r.Extra {
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+func (r *Record) UnmarshalJSON(data []byte) error {
+	type alias Record
+	var decoded alias
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, key := range []string{"created_at", "id", "name"} {
+		delete(fields, key)
+	}
+	decoded.Extra = nil
+	if len(fields) > 0 {
+		decoded.Extra = fields
+	}
+	*r = Record(decoded)
+	return nil
+}
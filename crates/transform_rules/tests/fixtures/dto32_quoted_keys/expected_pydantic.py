@@ -0,0 +1,7 @@
+from typing import Optional
+
+from pydantic import BaseModel, Field
+
+class Quote(BaseModel):
+    say_hi: str = Field(alias="say \"hi\"")
+    c_path: Optional[str] = Field(default=None, alias="C:\\path")
@@ -0,0 +1,9 @@
+from dataclasses import dataclass, field
+from typing import Optional
+
+@dataclass
+class Quote:
+    # json: "say \"hi\""
+    say_hi: str = field(metadata={"json_key": "say \"hi\""})
+    # json: "C:\\path"
+    c_path: Optional[str] = field(default=None, metadata={"json_key": "C:\\path"})
@@ -111,6 +111,7 @@ fn invalid_rules_should_match_expected_errors() {
         "v09_invalid_when_type",
         "v10_invalid_record_when_type",
         "v11_invalid_item_ref",
+        "v12_invalid_dto_type",
     ];
 
     for case in cases {
@@ -1,15 +1,23 @@
+mod watch;
+
 use std::fs;
 use std::io::{self, Write};
 use std::path::PathBuf;
+use std::time::Duration;
 
 use clap::{Args, Parser, Subcommand, ValueEnum};
 use serde_json::json;
 use transform_rules::{
-    generate_dto, parse_rule_file, preflight_validate_with_warnings, transform_stream,
-    transform_with_warnings, validate_rule_file_with_source, DtoLanguage, InputFormat, RuleError,
-    RuleFile, TransformError, TransformErrorKind, TransformWarning,
+    diff_generated_file, generate_dto_batch, generate_dto_batch_with_jobs,
+    generate_dto_from_json_schema_with_warnings, generate_dto_from_openapi_with_warnings,
+    generate_dto_with_warnings, json_schema_sources, parse_rule_file,
+    preflight_validate_with_warnings, transform_stream, transform_with_warnings,
+    validate_rule_file_with_source, BatchMode, DtoLanguage, DtoOptions, DtoWarning, GoHeader,
+    InputFormat, RuleError, RuleFile, TransformError, TransformErrorKind, TransformWarning,
 };
 
+use watch::Notifier;
+
 #[derive(Parser)]
 #[command(name = "transform-rules")]
 #[command(version, about = "Transform CSV/JSON data using YAML rules")]
@@ -24,6 +32,7 @@ enum Commands {
     Preflight(PreflightArgs),
     Transform(TransformArgs),
     Generate(GenerateArgs),
+    Watch(WatchArgs),
 }
 
 #[derive(Args)]
@@ -70,14 +79,49 @@ struct TransformArgs {
 
 #[derive(Args)]
 struct GenerateArgs {
-    #[arg(short = 'r', long)]
-    rules: PathBuf,
-    #[arg(short = 'l', long)]
+    #[arg(
+        short = 'r',
+        long,
+        required_unless_present_any = ["input", "schema", "batch"],
+        conflicts_with_all = ["input", "schema", "batch"]
+    )]
+    rules: Option<PathBuf>,
+    #[arg(short = 'i', long, conflicts_with_all = ["schema", "batch"])]
+    input: Option<PathBuf>,
+    #[arg(short = 's', long, conflicts_with = "batch")]
+    schema: Option<PathBuf>,
+    #[arg(short = 'b', long)]
+    batch: Option<PathBuf>,
+    #[arg(long, requires = "batch")]
+    merge: bool,
+    #[arg(short = 'j', long, requires = "batch")]
+    jobs: Option<usize>,
+    #[arg(short = 'l', long, visible_alias = "target")]
     lang: DtoLanguageArg,
-    #[arg(short = 'n', long)]
+    #[arg(short = 'n', long, conflicts_with = "input")]
     name: Option<String>,
-    #[arg(short = 'o', long)]
+    #[arg(short = 'p', long)]
+    package: Option<String>,
+    #[arg(long)]
+    template: Option<PathBuf>,
+    #[arg(long, default_value = "none")]
+    header: GoHeaderArg,
+    #[arg(short = 'o', long, visible_alias = "out")]
     output: Option<PathBuf>,
+    #[arg(long)]
+    strict: bool,
+    #[arg(long)]
+    merge_identical_types: bool,
+    #[arg(long, requires = "output", conflicts_with = "batch")]
+    check: bool,
+}
+
+#[derive(Args)]
+struct WatchArgs {
+    #[command(flatten)]
+    generate: GenerateArgs,
+    #[arg(long, default_value_t = 200)]
+    debounce_ms: u64,
 }
 
 #[derive(Clone, Copy, Debug, ValueEnum)]
@@ -92,16 +136,27 @@ enum FormatOverride {
     Json,
 }
 
+#[derive(Clone, Copy, Debug, ValueEnum)]
+enum GoHeaderArg {
+    None,
+    DoNotEdit,
+    SourceHash,
+}
+
 #[derive(Clone, Copy, Debug, ValueEnum)]
 enum DtoLanguageArg {
     Rust,
     #[value(alias = "ts")]
     TypeScript,
     Python,
+    Pydantic,
     Go,
     Java,
     Kotlin,
     Swift,
+    #[value(alias = "proto")]
+    Protobuf,
+    JsonSchema,
 }
 
 fn main() {
@@ -110,7 +165,8 @@ fn main() {
         Commands::Validate(args) => run_validate(args),
         Commands::Preflight(args) => run_preflight(args),
         Commands::Transform(args) => run_transform(args),
-        Commands::Generate(args) => run_generate(args),
+        Commands::Generate(args) => run_generate(&args),
+        Commands::Watch(args) => run_watch(args),
     };
     std::process::exit(exit_code);
 }
@@ -310,31 +366,97 @@ fn run_transform_ndjson(
     0
 }
 
-fn run_generate(args: GenerateArgs) -> i32 {
-    let (rule, _) = match load_rule(&args.rules) {
-        Ok(value) => value,
-        Err(code) => return code,
-    };
-
+fn run_generate(args: &GenerateArgs) -> i32 {
     let lang = match args.lang {
         DtoLanguageArg::Rust => DtoLanguage::Rust,
         DtoLanguageArg::TypeScript => DtoLanguage::TypeScript,
         DtoLanguageArg::Python => DtoLanguage::Python,
+        DtoLanguageArg::Pydantic => DtoLanguage::Pydantic,
         DtoLanguageArg::Go => DtoLanguage::Go,
         DtoLanguageArg::Java => DtoLanguage::Java,
         DtoLanguageArg::Kotlin => DtoLanguage::Kotlin,
         DtoLanguageArg::Swift => DtoLanguage::Swift,
+        DtoLanguageArg::Protobuf => DtoLanguage::Protobuf,
+        DtoLanguageArg::JsonSchema => DtoLanguage::JsonSchema,
     };
 
-    let output = match generate_dto(&rule, lang, args.name.as_deref()) {
-        Ok(text) => text,
+    let mut options = DtoOptions {
+        strict: args.strict,
+        merge_identical_types: args.merge_identical_types,
+        ..DtoOptions::default()
+    };
+    if let Some(package) = args.package.clone() {
+        options.go.package_name = package;
+    }
+    options.go.header = match args.header {
+        GoHeaderArg::None => GoHeader::None,
+        GoHeaderArg::DoNotEdit => GoHeader::DoNotEdit,
+        GoHeaderArg::SourceHash => GoHeader::WithSourceHash,
+    };
+    if let Some(path) = &args.template {
+        match fs::read_to_string(path) {
+            Ok(template) => options.go.template = Some(template),
+            Err(err) => {
+                eprintln!("failed to read template: {}", err);
+                return 1;
+            }
+        }
+    }
+
+    if let Some(dir) = &args.batch {
+        return run_generate_batch(dir, lang, &options, args);
+    }
+
+    let result = match (&args.input, &args.schema, &args.rules) {
+        (Some(path), _, _) => {
+            let source = match load_input(path) {
+                Ok(value) => value,
+                Err(code) => return code,
+            };
+            generate_dto_from_openapi_with_warnings(&source, lang, &options)
+        }
+        (None, Some(path), _) => {
+            generate_dto_from_json_schema_with_warnings(path, lang, args.name.as_deref(), &options)
+        }
+        (None, None, Some(path)) => {
+            let (rule, _) = match load_rule(path) {
+                Ok(value) => value,
+                Err(code) => return code,
+            };
+            generate_dto_with_warnings(&rule, lang, args.name.as_deref(), &options)
+        }
+        (None, None, None) => {
+            eprintln!("one of --rules, --input, --schema or --batch is required");
+            return 1;
+        }
+    };
+
+    let output = match result {
+        Ok((text, warnings)) => {
+            emit_dto_warnings(&warnings);
+            text
+        }
         Err(err) => {
             eprintln!("failed to generate dto: {}", err);
             return 1;
         }
     };
 
-    if let Some(path) = args.output {
+    if let (true, Some(path)) = (args.check, &args.output) {
+        return match diff_generated_file(path, &output) {
+            Ok(None) => 0,
+            Ok(Some(diff)) => {
+                print!("{}", diff);
+                1
+            }
+            Err(err) => {
+                eprintln!("failed to check output: {}", err);
+                1
+            }
+        };
+    }
+
+    if let Some(path) = &args.output {
         if let Some(parent) = path.parent() {
             if !parent.as_os_str().is_empty() {
                 if let Err(err) = fs::create_dir_all(parent) {
@@ -343,7 +465,7 @@ fn run_generate(args: GenerateArgs) -> i32 {
                 }
             }
         }
-        if let Err(err) = fs::write(&path, output.as_bytes()) {
+        if let Err(err) = fs::write(path, output.as_bytes()) {
             eprintln!("failed to write output: {}", err);
             return 1;
         }
@@ -354,6 +476,123 @@ fn run_generate(args: GenerateArgs) -> i32 {
     0
 }
 
+fn run_generate_batch(
+    dir: &PathBuf,
+    lang: DtoLanguage,
+    options: &DtoOptions,
+    args: &GenerateArgs,
+) -> i32 {
+    let mode = if args.merge {
+        BatchMode::Merged
+    } else {
+        BatchMode::PerFile
+    };
+    if mode == BatchMode::PerFile && args.output.is_none() {
+        eprintln!("--batch without --merge requires --output <DIR>");
+        return 1;
+    }
+
+    let result = match args.jobs {
+        Some(jobs) => generate_dto_batch_with_jobs(dir, lang, options, mode, jobs),
+        None => generate_dto_batch(dir, lang, options, mode),
+    };
+    let batch = match result {
+        Ok(batch) => batch,
+        Err(err) => {
+            eprintln!("failed to generate dto: {}", err);
+            return 1;
+        }
+    };
+
+    for output in &batch.outputs {
+        emit_dto_warnings(&output.warnings);
+        let path = match (&args.output, mode) {
+            (Some(dir), BatchMode::PerFile) => dir.join(&output.file_name),
+            (Some(path), BatchMode::Merged) => path.clone(),
+            (None, _) => {
+                println!("{}", output.code);
+                continue;
+            }
+        };
+        if let Some(parent) = path.parent() {
+            if !parent.as_os_str().is_empty() {
+                if let Err(err) = fs::create_dir_all(parent) {
+                    eprintln!("failed to create output directory: {}", err);
+                    return 1;
+                }
+            }
+        }
+        if let Err(err) = fs::write(&path, output.code.as_bytes()) {
+            eprintln!("failed to write output: {}", err);
+            return 1;
+        }
+    }
+
+    for failure in &batch.failures {
+        eprintln!("failed to generate dto: {}: {}", failure.source.display(), failure.error);
+    }
+    if batch.failures.is_empty() {
+        0
+    } else {
+        1
+    }
+}
+
+fn run_watch(args: WatchArgs) -> i32 {
+    let debounce = Duration::from_millis(args.debounce_ms);
+    let args = &args.generate;
+    let sources = [&args.input, &args.schema, &args.rules, &args.batch];
+    if sources.iter().all(|source| source.is_none()) {
+        eprintln!("one of --rules, --input, --schema or --batch is required");
+        return 1;
+    }
+    loop {
+        let paths = watch_paths(args);
+        let mut notifier = match Notifier::new(&paths, args.output.as_deref()) {
+            Ok(notifier) => notifier,
+            Err(err) => {
+                eprintln!("failed to watch: {}", err);
+                return 1;
+            }
+        };
+        if run_generate(args) == 0 {
+            eprintln!("generated; watching for changes");
+        } else {
+            eprintln!("generation failed; watching for changes");
+        }
+        if let Err(err) = wait_for_change(&mut notifier, debounce) {
+            eprintln!("failed to watch: {}", err);
+            return 1;
+        }
+    }
+}
+
+fn watch_paths(args: &GenerateArgs) -> Vec<PathBuf> {
+    let mut paths = Vec::new();
+    match (&args.input, &args.schema, &args.rules, &args.batch) {
+        (Some(path), _, _, _) | (_, _, Some(path), _) => paths.push(path.clone()),
+        (_, Some(path), _, _) => {
+            match path.parent() {
+                Some(dir) if !dir.as_os_str().is_empty() => paths.push(dir.to_path_buf()),
+                _ => paths.push(PathBuf::from(".")),
+            }
+            paths.extend(json_schema_sources(path));
+        }
+        (_, _, _, Some(dir)) => paths.push(dir.clone()),
+        (None, None, None, None) => {}
+    }
+    if let Some(path) = &args.template {
+        paths.push(path.clone());
+    }
+    paths
+}
+
+fn wait_for_change(notifier: &mut Notifier, debounce: Duration) -> io::Result<()> {
+    notifier.wait(None)?;
+    while notifier.wait(Some(debounce))? {}
+    Ok(())
+}
+
 fn load_rule(path: &PathBuf) -> Result<(RuleFile, String), i32> {
     let yaml = match fs::read_to_string(path) {
         Ok(data) => data,
@@ -481,11 +720,20 @@ fn emit_transform_error(err: &TransformError, format: ErrorFormat) {
             if let Some(path) = &err.path {
                 value["path"] = json!(path);
             }
-            eprintln!("{}", serde_json::to_string(&vec![value]).unwrap_or_default());
+            eprintln!(
+                "{}",
+                serde_json::to_string(&vec![value]).unwrap_or_default()
+            );
         }
     }
 }
 
+fn emit_dto_warnings(warnings: &[DtoWarning]) {
+    for warning in warnings {
+        eprintln!("W path={} msg=\"{}\"", warning.path, warning.message);
+    }
+}
+
 fn emit_transform_warnings(warnings: &[TransformWarning], format: ErrorFormat) {
     if warnings.is_empty() {
         return;
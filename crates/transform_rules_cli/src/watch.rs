@@ -0,0 +1,259 @@
+use std::ffi::OsString;
+use std::io;
+use std::path::{Component, Path, PathBuf};
+use std::time::Duration;
+
+pub struct Notifier {
+    inner: imp::Notifier,
+}
+
+impl Notifier {
+    pub fn new(paths: &[PathBuf], output: Option<&Path>) -> io::Result<Self> {
+        let output = output.map(normalize);
+        let targets = paths
+            .iter()
+            .map(|path| {
+                if path.is_dir() {
+                    let filter = Filter::Sources(output.clone());
+                    (path.clone(), filter)
+                } else {
+                    let dir = match path.parent() {
+                        Some(dir) if !dir.as_os_str().is_empty() => dir.to_path_buf(),
+                        _ => PathBuf::from("."),
+                    };
+                    let name = path.file_name().unwrap_or_default().to_os_string();
+                    (dir, Filter::File(name))
+                }
+            })
+            .collect();
+        Ok(Self {
+            inner: imp::Notifier::new(targets)?,
+        })
+    }
+
+    pub fn wait(&mut self, timeout: Option<Duration>) -> io::Result<bool> {
+        self.inner.wait(timeout)
+    }
+}
+
+enum Filter {
+    Sources(Option<PathBuf>),
+    File(OsString),
+}
+
+impl Filter {
+    fn accepts(&self, path: &Path) -> bool {
+        match self {
+            Filter::Sources(output) => {
+                let extension = path.extension().and_then(|ext| ext.to_str()).unwrap_or("");
+                let is_output = output
+                    .as_ref()
+                    .is_some_and(|output| normalize(path).starts_with(output));
+                !is_output && matches!(extension, "yaml" | "yml" | "json")
+            }
+            Filter::File(name) => path.file_name() == Some(name.as_os_str()),
+        }
+    }
+}
+
+fn normalize(path: &Path) -> PathBuf {
+    path.components()
+        .filter(|component| !matches!(component, Component::CurDir))
+        .collect()
+}
+
+#[cfg(target_os = "linux")]
+mod imp {
+    use std::collections::HashMap;
+    use std::ffi::{CString, OsStr};
+    use std::io;
+    use std::mem;
+    use std::os::unix::ffi::OsStrExt;
+    use std::path::PathBuf;
+    use std::ptr;
+    use std::time::{Duration, Instant};
+
+    use super::Filter;
+
+    const EVENTS: u32 = libc::IN_CLOSE_WRITE
+        | libc::IN_MOVED_TO
+        | libc::IN_MOVED_FROM
+        | libc::IN_CREATE
+        | libc::IN_DELETE;
+
+    pub struct Notifier {
+        fd: i32,
+        watches: HashMap<i32, (PathBuf, Vec<Filter>)>,
+    }
+
+    impl Notifier {
+        pub fn new(targets: Vec<(PathBuf, Filter)>) -> io::Result<Self> {
+            let fd = unsafe { libc::inotify_init1(libc::IN_CLOEXEC | libc::IN_NONBLOCK) };
+            if fd < 0 {
+                return Err(io::Error::last_os_error());
+            }
+            let mut notifier = Self {
+                fd,
+                watches: HashMap::new(),
+            };
+            for (dir, filter) in targets {
+                let path = CString::new(dir.as_os_str().as_bytes())?;
+                let wd = unsafe { libc::inotify_add_watch(fd, path.as_ptr(), EVENTS) };
+                if wd < 0 {
+                    let err = io::Error::last_os_error();
+                    return Err(io::Error::new(
+                        err.kind(),
+                        format!("{}: {}", dir.display(), err),
+                    ));
+                }
+                notifier
+                    .watches
+                    .entry(wd)
+                    .or_insert_with(|| (dir, Vec::new()))
+                    .1
+                    .push(filter);
+            }
+            Ok(notifier)
+        }
+
+        pub fn wait(&mut self, timeout: Option<Duration>) -> io::Result<bool> {
+            let deadline = timeout.map(|timeout| Instant::now() + timeout);
+            loop {
+                let timeout_ms = match deadline {
+                    Some(deadline) => {
+                        let remaining = deadline.saturating_duration_since(Instant::now());
+                        remaining.as_millis().min(i32::MAX as u128) as i32
+                    }
+                    None => -1,
+                };
+                let mut pollfd = libc::pollfd {
+                    fd: self.fd,
+                    events: libc::POLLIN,
+                    revents: 0,
+                };
+                let ready = unsafe { libc::poll(&mut pollfd, 1, timeout_ms) };
+                if ready < 0 {
+                    let err = io::Error::last_os_error();
+                    if err.kind() == io::ErrorKind::Interrupted {
+                        continue;
+                    }
+                    return Err(err);
+                }
+                if ready == 0 {
+                    return Ok(false);
+                }
+                if self.read_events()? {
+                    return Ok(true);
+                }
+            }
+        }
+
+        fn read_events(&self) -> io::Result<bool> {
+            let mut buffer = [0u8; 4096];
+            let header = mem::size_of::<libc::inotify_event>();
+            let mut matched = false;
+            loop {
+                let len = unsafe { libc::read(self.fd, buffer.as_mut_ptr().cast(), buffer.len()) };
+                if len < 0 {
+                    let err = io::Error::last_os_error();
+                    match err.kind() {
+                        io::ErrorKind::WouldBlock => return Ok(matched),
+                        io::ErrorKind::Interrupted => continue,
+                        _ => return Err(err),
+                    }
+                }
+                let len = len as usize;
+                let mut offset = 0;
+                while offset + header <= len {
+                    let event: libc::inotify_event =
+                        unsafe { ptr::read_unaligned(buffer.as_ptr().add(offset).cast()) };
+                    let name_end = (offset + header + event.len as usize).min(len);
+                    let name = &buffer[offset + header..name_end];
+                    let name_len = name
+                        .iter()
+                        .position(|byte| *byte == 0)
+                        .unwrap_or(name.len());
+                    let name = &name[..name_len];
+                    offset = name_end;
+                    if let Some((dir, filters)) = self.watches.get(&event.wd) {
+                        let path = dir.join(OsStr::from_bytes(name));
+                        matched |= filters.iter().any(|filter| filter.accepts(&path));
+                    }
+                }
+            }
+        }
+    }
+
+    impl Drop for Notifier {
+        fn drop(&mut self) {
+            unsafe {
+                libc::close(self.fd);
+            }
+        }
+    }
+}
+
+#[cfg(not(target_os = "linux"))]
+mod imp {
+    use std::collections::BTreeMap;
+    use std::fs;
+    use std::io;
+    use std::path::{Path, PathBuf};
+    use std::thread;
+    use std::time::{Duration, Instant, SystemTime};
+
+    use super::Filter;
+
+    const POLL_INTERVAL: Duration = Duration::from_millis(50);
+
+    type Snapshot = BTreeMap<PathBuf, Option<(SystemTime, u64)>>;
+
+    pub struct Notifier {
+        targets: Vec<(PathBuf, Filter)>,
+        last: Snapshot,
+    }
+
+    impl Notifier {
+        pub fn new(targets: Vec<(PathBuf, Filter)>) -> io::Result<Self> {
+            let last = snapshot(&targets);
+            Ok(Self { targets, last })
+        }
+
+        pub fn wait(&mut self, timeout: Option<Duration>) -> io::Result<bool> {
+            let deadline = timeout.map(|timeout| Instant::now() + timeout);
+            loop {
+                thread::sleep(POLL_INTERVAL);
+                let current = snapshot(&self.targets);
+                if current != self.last {
+                    self.last = current;
+                    return Ok(true);
+                }
+                if deadline.is_some_and(|deadline| Instant::now() >= deadline) {
+                    return Ok(false);
+                }
+            }
+        }
+    }
+
+    fn snapshot(targets: &[(PathBuf, Filter)]) -> Snapshot {
+        let mut snapshot = Snapshot::new();
+        for (dir, filter) in targets {
+            let Ok(entries) = fs::read_dir(dir) else {
+                continue;
+            };
+            for entry in entries.flatten() {
+                let path = entry.path();
+                if filter.accepts(&path) {
+                    let stamp = file_stamp(&path);
+                    snapshot.insert(path, stamp);
+                }
+            }
+        }
+        snapshot
+    }
+
+    fn file_stamp(path: &Path) -> Option<(SystemTime, u64)> {
+        let metadata = fs::metadata(path).ok()?;
+        Some((metadata.modified().ok()?, metadata.len()))
+    }
+}
@@ -1,5 +1,8 @@
 use std::fs;
-use std::path::PathBuf;
+use std::path::{Path, PathBuf};
+use std::process::{Command, Stdio};
+use std::thread;
+use std::time::{Duration, Instant};
 
 use assert_cmd::cargo::cargo_bin_cmd;
 
@@ -242,3 +245,353 @@ fn generate_outputs_rust_dto() {
     let stdout = String::from_utf8(output.stdout).unwrap();
     assert!(stdout.contains("struct Record"));
 }
+
+#[test]
+fn generate_writes_go_from_openapi_input() {
+    let input = fixtures_dir()
+        .join("dto09_openapi_basic")
+        .join("openapi.yaml");
+    let temp_dir = tempfile::tempdir().unwrap();
+    let out_path = temp_dir.path().join("models").join("record.go");
+
+    let mut cmd = cargo_bin_cmd!("transform-rules");
+    let output = cmd
+        .arg("generate")
+        .arg("--input")
+        .arg(input)
+        .arg("--target")
+        .arg("go")
+        .arg("--package")
+        .arg("models")
+        .arg("--out")
+        .arg(&out_path)
+        .output()
+        .unwrap();
+
+    assert_eq!(output.status.code(), Some(0));
+    let contents = fs::read_to_string(&out_path)
+        .unwrap_or_else(|_| panic!("failed to read {}", out_path.display()));
+    assert!(contents.starts_with("package models\n"));
+    assert!(contents.contains("type User struct"));
+}
+
+#[test]
+fn generate_renders_go_template() {
+    let base = fixtures_dir().join("dto26_go_template");
+
+    let mut cmd = cargo_bin_cmd!("transform-rules");
+    let output = cmd
+        .arg("generate")
+        .arg("--input")
+        .arg(base.join("openapi.yaml"))
+        .arg("--lang")
+        .arg("go")
+        .arg("--template")
+        .arg(base.join("template.gotmpl"))
+        .output()
+        .unwrap();
+
+    assert_eq!(output.status.code(), Some(0));
+    let stdout = String::from_utf8(output.stdout).unwrap();
+    assert!(stdout.starts_with("// ===="));
+    assert!(stdout.contains("// ---- Order ----\n"));
+}
+
+#[test]
+fn generate_writes_go_from_json_schema() {
+    let schema = fixtures_dir()
+        .join("dto13_json_schema_refs")
+        .join("schema.json");
+
+    let mut cmd = cargo_bin_cmd!("transform-rules");
+    let output = cmd
+        .arg("generate")
+        .arg("--schema")
+        .arg(schema)
+        .arg("--lang")
+        .arg("go")
+        .output()
+        .unwrap();
+
+    assert_eq!(output.status.code(), Some(0));
+    let stdout = String::from_utf8_lossy(&output.stdout);
+    assert_eq!(stdout.matches("type Address struct").count(), 1);
+    assert!(stdout.contains("type Order struct"));
+}
+
+#[test]
+fn generate_warns_on_fallback_types() {
+    let rules = fixtures_dir().join("dto01_basic").join("rules.yaml");
+
+    let mut cmd = cargo_bin_cmd!("transform-rules");
+    let output = cmd
+        .arg("generate")
+        .arg("-r")
+        .arg(&rules)
+        .arg("-l")
+        .arg("go")
+        .output()
+        .unwrap();
+    assert_eq!(output.status.code(), Some(0));
+    let stderr = String::from_utf8_lossy(&output.stderr);
+    assert!(stderr.contains(
+        "W path=Record.meta msg=\"type could not be inferred, fell back to json.RawMessage\""
+    ));
+
+    let mut cmd = cargo_bin_cmd!("transform-rules");
+    let output = cmd
+        .arg("generate")
+        .arg("-r")
+        .arg(&rules)
+        .arg("-l")
+        .arg("go")
+        .arg("--strict")
+        .output()
+        .unwrap();
+    assert_eq!(output.status.code(), Some(1));
+    assert!(output.stdout.is_empty());
+    let stderr = String::from_utf8_lossy(&output.stderr);
+    assert!(stderr.contains("Record.user.name: type could not be inferred"));
+}
+
+#[test]
+fn generate_batch_writes_per_file_and_merged_output() {
+    let inputs = fixtures_dir().join("dto19_batch").join("inputs");
+    let temp_dir = tempfile::tempdir().unwrap();
+    let batch_dir = temp_dir.path().join("schemas");
+    fs::create_dir_all(&batch_dir).unwrap();
+    for name in ["orders.yaml", "customers.yaml"] {
+        fs::copy(inputs.join(name), batch_dir.join(name)).unwrap();
+    }
+    let out_dir = temp_dir.path().join("out");
+
+    let mut cmd = cargo_bin_cmd!("transform-rules");
+    let output = cmd
+        .arg("generate")
+        .arg("--batch")
+        .arg(&batch_dir)
+        .arg("--lang")
+        .arg("go")
+        .arg("--output")
+        .arg(&out_dir)
+        .output()
+        .unwrap();
+    assert_eq!(output.status.code(), Some(0));
+    assert!(out_dir.join("orders.go").exists());
+    assert!(out_dir.join("customers.go").exists());
+
+    let merged = temp_dir.path().join("dto.go");
+    let mut cmd = cargo_bin_cmd!("transform-rules");
+    let output = cmd
+        .arg("generate")
+        .arg("--batch")
+        .arg(&batch_dir)
+        .arg("--merge")
+        .arg("--lang")
+        .arg("go")
+        .arg("--output")
+        .arg(&merged)
+        .output()
+        .unwrap();
+    assert_eq!(output.status.code(), Some(0));
+    let contents = fs::read_to_string(&merged).unwrap();
+    assert_eq!(contents.matches("type RecordUser struct").count(), 1);
+    assert!(contents.contains("type Record2 struct"));
+}
+
+#[test]
+fn generate_batch_reports_failures_per_file() {
+    let inputs = fixtures_dir().join("dto19_batch").join("inputs");
+    let temp_dir = tempfile::tempdir().unwrap();
+
+    let mut cmd = cargo_bin_cmd!("transform-rules");
+    let output = cmd
+        .arg("generate")
+        .arg("--batch")
+        .arg(&inputs)
+        .arg("--lang")
+        .arg("go")
+        .arg("--output")
+        .arg(temp_dir.path())
+        .output()
+        .unwrap();
+    assert_eq!(output.status.code(), Some(1));
+    assert!(temp_dir.path().join("orders.go").exists());
+    let stderr = String::from_utf8_lossy(&output.stderr);
+    assert!(stderr.contains("broken.yaml: invalid YAML"));
+}
+
+#[test]
+fn generate_check_compares_existing_output() {
+    let input = fixtures_dir()
+        .join("dto09_openapi_basic")
+        .join("openapi.yaml");
+    let temp_dir = tempfile::tempdir().unwrap();
+    let out_path = temp_dir.path().join("record.go");
+    let generate = |check: bool| {
+        let mut cmd = cargo_bin_cmd!("transform-rules");
+        cmd.arg("generate")
+            .arg("--input")
+            .arg(&input)
+            .arg("--lang")
+            .arg("go")
+            .arg("--out")
+            .arg(&out_path);
+        if check {
+            cmd.arg("--check");
+        }
+        cmd.output().unwrap()
+    };
+
+    let output = generate(true);
+    assert_eq!(output.status.code(), Some(1));
+    assert!(!out_path.exists());
+
+    assert_eq!(generate(false).status.code(), Some(0));
+    let output = generate(true);
+    assert_eq!(output.status.code(), Some(0));
+    assert!(output.stdout.is_empty());
+    assert!(output.stderr.is_empty());
+
+    let contents = fs::read_to_string(&out_path).unwrap();
+    fs::write(&out_path, contents.replace("type User struct", "type Account struct")).unwrap();
+    let output = generate(true);
+    assert_eq!(output.status.code(), Some(1));
+    let stdout = String::from_utf8_lossy(&output.stdout);
+    assert!(stdout.contains("(generated)\n@@ "));
+    assert!(stdout.contains("\n-type Account struct {\n+type User struct {\n"));
+    assert_eq!(
+        fs::read_to_string(&out_path).unwrap(),
+        contents.replace("type User struct", "type Account struct")
+    );
+}
+
+#[test]
+fn generate_reports_offending_field() {
+    let temp_dir = tempfile::tempdir().unwrap();
+    let input = temp_dir.path().join("openapi.yaml");
+    fs::write(
+        &input,
+        r#"openapi: "3.0.3"
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        age:
+          type: decimal
+"#,
+    )
+    .unwrap();
+
+    let mut cmd = cargo_bin_cmd!("transform-rules");
+    let output = cmd
+        .arg("generate")
+        .arg("--input")
+        .arg(input)
+        .arg("--target")
+        .arg("go")
+        .output()
+        .unwrap();
+
+    assert_eq!(output.status.code(), Some(1));
+    let stderr = String::from_utf8(output.stderr).unwrap();
+    assert!(stderr.contains("User.age"), "stderr: {}", stderr);
+}
+
+fn wait_for_output(path: &Path, needle: &str) -> bool {
+    let deadline = Instant::now() + Duration::from_secs(10);
+    while Instant::now() < deadline {
+        if fs::read_to_string(path).is_ok_and(|contents| contents.contains(needle)) {
+            return true;
+        }
+        thread::sleep(Duration::from_millis(50));
+    }
+    false
+}
+
+#[test]
+fn watch_regenerates_on_change() {
+    let temp_dir = tempfile::tempdir().unwrap();
+    let rules = temp_dir.path().join("rules.yaml");
+    fs::copy(
+        fixtures_dir()
+            .join("dto28_merge_identical_types")
+            .join("rules.yaml"),
+        &rules,
+    )
+    .unwrap();
+    let out_path = temp_dir.path().join("out").join("dto.go");
+
+    let mut child = Command::new(env!("CARGO_BIN_EXE_transform-rules"))
+        .arg("watch")
+        .arg("--rules")
+        .arg(&rules)
+        .arg("--lang")
+        .arg("go")
+        .arg("--output")
+        .arg(&out_path)
+        .arg("--debounce-ms")
+        .arg("50")
+        .stderr(Stdio::null())
+        .spawn()
+        .unwrap();
+
+    let generated = wait_for_output(&out_path, "type RecordReviewer struct");
+    let contents = fs::read_to_string(&rules).unwrap();
+    fs::write(&rules, contents.replace("reviewer.", "auditor.")).unwrap();
+    let regenerated = wait_for_output(&out_path, "type RecordAuditor struct");
+    child.kill().unwrap();
+    child.wait().unwrap();
+
+    assert!(generated);
+    assert!(regenerated);
+}
+
+#[test]
+fn watch_regenerates_on_external_ref_change() {
+    let temp_dir = tempfile::tempdir().unwrap();
+    let schema_dir = temp_dir.path().join("schemas");
+    let shared_dir = temp_dir.path().join("shared");
+    fs::create_dir_all(&schema_dir).unwrap();
+    fs::create_dir_all(&shared_dir).unwrap();
+    let schema = schema_dir.join("order.json");
+    let common = shared_dir.join("common.json");
+    fs::write(
+        &schema,
+        r#"{"title": "Order", "type": "object",
+            "properties": {"shipping": {"$ref": "../shared/common.json#/$defs/Address"}}}"#,
+    )
+    .unwrap();
+    fs::write(
+        &common,
+        r#"{"$defs": {"Address": {"type": "object",
+            "properties": {"street": {"type": "string"}}}}}"#,
+    )
+    .unwrap();
+    let out_path = temp_dir.path().join("out").join("dto.go");
+
+    let mut child = Command::new(env!("CARGO_BIN_EXE_transform-rules"))
+        .arg("watch")
+        .arg("--schema")
+        .arg(&schema)
+        .arg("--lang")
+        .arg("go")
+        .arg("--output")
+        .arg(&out_path)
+        .arg("--debounce-ms")
+        .arg("50")
+        .stderr(Stdio::null())
+        .spawn()
+        .unwrap();
+
+    let generated = wait_for_output(&out_path, "Street");
+    let contents = fs::read_to_string(&common).unwrap();
+    fs::write(&common, contents.replace("street", "avenue")).unwrap();
+    let regenerated = wait_for_output(&out_path, "Avenue");
+    child.kill().unwrap();
+    child.wait().unwrap();
+
+    assert!(generated);
+    assert!(regenerated);
+}
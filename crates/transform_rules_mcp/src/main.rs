@@ -542,7 +542,7 @@ fn generate_dto_input_schema() -> Value {
             },
             "language": {
                 "type": "string",
-                "enum": ["rust", "typescript", "python", "go", "java", "kotlin", "swift"],
+                "enum": ["rust", "typescript", "python", "pydantic", "go", "java", "kotlin", "swift", "protobuf", "json-schema"],
                 "description": "DTO output language.",
                 "examples": ["typescript"]
             },
@@ -1984,12 +1984,18 @@ fn parse_dto_language(value: &str) -> Result<DtoLanguage, String> {
         "rust" => Ok(DtoLanguage::Rust),
         "typescript" => Ok(DtoLanguage::TypeScript),
         "python" => Ok(DtoLanguage::Python),
+        "pydantic" => Ok(DtoLanguage::Pydantic),
         "go" => Ok(DtoLanguage::Go),
         "java" => Ok(DtoLanguage::Java),
         "kotlin" => Ok(DtoLanguage::Kotlin),
         "swift" => Ok(DtoLanguage::Swift),
-        _ => Err("language must be one of rust, typescript, python, go, java, kotlin, swift"
-            .to_string()),
+        "protobuf" => Ok(DtoLanguage::Protobuf),
+        "json-schema" => Ok(DtoLanguage::JsonSchema),
+        _ => Err(
+            "language must be one of rust, typescript, python, pydantic, go, java, kotlin, swift, \
+             protobuf, json-schema"
+                .to_string(),
+        ),
     }
 }
 
@@ -1998,10 +2004,13 @@ fn dto_language_to_str(language: DtoLanguage) -> &'static str {
         DtoLanguage::Rust => "rust",
         DtoLanguage::TypeScript => "typescript",
         DtoLanguage::Python => "python",
+        DtoLanguage::Pydantic => "pydantic",
         DtoLanguage::Go => "go",
         DtoLanguage::Java => "java",
         DtoLanguage::Kotlin => "kotlin",
         DtoLanguage::Swift => "swift",
+        DtoLanguage::Protobuf => "protobuf",
+        DtoLanguage::JsonSchema => "json-schema",
     }
 }
 